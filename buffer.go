@@ -0,0 +1,219 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// DefaultBufferSize is the buffer size used when BufferingConfig.Size is unset.
+	DefaultBufferSize = 256 * 1024
+
+	// DefaultFlushInterval is the flush interval used when BufferingConfig.FlushInterval is unset.
+	DefaultFlushInterval = time.Second
+
+	// BufferBlock is the BufferingConfig.OnFull value that makes writers wait for
+	// room in a full buffer.  This is the default.
+	BufferBlock = "block"
+
+	// BufferDrop is the BufferingConfig.OnFull value that discards log entries
+	// written to a full buffer.
+	BufferDrop = "drop"
+)
+
+// BufferingConfig describes how output is buffered.  When buffering is enabled, each
+// log entry is copied into a buffer and written to the output sinks by a background
+// goroutine, so that logging does not wait on slow disks or pipes.
+//
+// Buffered output is written when the buffer fills, at each FlushInterval, and whenever
+// the logger is synced.  Entries still in the buffer when the process exits without a
+// sync are lost.  The SyncOnShutdown fx option syncs the logger and then stops buffering.
+//
+// Syslog, memory, and tail output paths are not buffered, as each of their messages carries
+// the metadata of its entry.
+type BufferingConfig struct {
+	// Size is the buffer size in bytes for each core.  If unset, DefaultBufferSize is used.
+	Size int `json:"size,omitempty" yaml:"size,omitempty"`
+
+	// FlushInterval is the longest time that output stays buffered.  If unset,
+	// DefaultFlushInterval is used.
+	FlushInterval time.Duration `json:"flushInterval,omitempty" yaml:"flushInterval,omitempty"`
+
+	// OnFull is what happens when an entry is written to a full buffer:  either
+	// BufferBlock or BufferDrop.  If unset, BufferBlock is used.
+	OnFull string `json:"onFull,omitempty" yaml:"onFull,omitempty"`
+}
+
+// BufferedWriteSyncer is a zapcore.WriteSyncer that buffers writes and flushes them
+// asynchronously to another zapcore.WriteSyncer.  Config.Buffering wraps output sinks
+// in one of these.
+//
+// A BufferedWriteSyncer is safe for concurrent writes.  No additional synchronization is required.
+type BufferedWriteSyncer struct {
+	ws   zapcore.WriteSyncer
+	size int
+	drop bool
+
+	// dropped is the number of writes discarded because the buffer was full
+	dropped atomic.Uint64
+
+	lock    sync.Mutex
+	space   *sync.Cond
+	buf     []byte
+	spare   []byte
+	stopped bool
+
+	// flushLock serializes writes to the underlying WriteSyncer
+	flushLock sync.Mutex
+
+	wake     chan struct{}
+	done     chan struct{}
+	finished chan struct{}
+	stopOnce sync.Once
+}
+
+var _ zapcore.WriteSyncer = (*BufferedWriteSyncer)(nil)
+
+// NewBufferedWriteSyncer starts buffering output to the given WriteSyncer.  Any OnFull
+// other than BufferDrop makes writers wait for room in a full buffer.  The returned
+// BufferedWriteSyncer must be stopped with Stop to release its background goroutine.
+func NewBufferedWriteSyncer(ws zapcore.WriteSyncer, bc BufferingConfig) *BufferedWriteSyncer {
+	bws := &BufferedWriteSyncer{
+		ws:       ws,
+		size:     bc.Size,
+		drop:     bc.OnFull == BufferDrop,
+		wake:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+
+	if bws.size <= 0 {
+		bws.size = DefaultBufferSize
+	}
+
+	interval := bc.FlushInterval
+	if interval <= 0 {
+		interval = DefaultFlushInterval
+	}
+
+	bws.space = sync.NewCond(&bws.lock)
+	go bws.run(interval)
+	return bws
+}
+
+// run flushes the buffer whenever it fills or the interval elapses.
+func (bws *BufferedWriteSyncer) run(interval time.Duration) {
+	defer close(bws.finished)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-bws.done:
+			return
+
+		case <-bws.wake:
+			bws.flush(false)
+
+		case <-ticker.C:
+			bws.flush(false)
+		}
+	}
+}
+
+// signal wakes the background goroutine without blocking.
+func (bws *BufferedWriteSyncer) signal() {
+	select {
+	case bws.wake <- struct{}{}:
+	default:
+	}
+}
+
+// flush writes everything buffered so far to the underlying WriteSyncer.  If stop is set,
+// buffering is stopped under the same lock that Write checks, so that nothing can be
+// buffered after this final flush.  The flushLock is held throughout, so writes made
+// directly to the underlying WriteSyncer never get ahead of the buffered output.
+func (bws *BufferedWriteSyncer) flush(stop bool) (err error) {
+	bws.flushLock.Lock()
+	defer bws.flushLock.Unlock()
+
+	bws.lock.Lock()
+	bws.stopped = bws.stopped || stop
+	data := bws.buf
+	bws.buf, bws.spare = bws.spare[:0], nil
+	bws.space.Broadcast()
+	bws.lock.Unlock()
+
+	if len(data) > 0 {
+		_, err = bws.ws.Write(data)
+	}
+
+	bws.lock.Lock()
+	bws.spare = data[:0]
+	bws.lock.Unlock()
+	return
+}
+
+// Write copies p into the buffer.  If the buffer is full, this method either waits for
+// the buffer to be flushed or discards p.  Once buffering has been stopped, p is written
+// directly to the underlying WriteSyncer.
+func (bws *BufferedWriteSyncer) Write(p []byte) (int, error) {
+	bws.lock.Lock()
+	for !bws.stopped && len(bws.buf) > 0 && len(bws.buf)+len(p) > bws.size {
+		if bws.drop {
+			bws.lock.Unlock()
+			bws.dropped.Add(1)
+			return len(p), nil
+		}
+
+		bws.signal()
+		bws.space.Wait()
+	}
+
+	if bws.stopped {
+		bws.lock.Unlock()
+		bws.flushLock.Lock()
+		defer bws.flushLock.Unlock()
+		return bws.ws.Write(p)
+	}
+
+	bws.buf = append(bws.buf, p...)
+	if len(bws.buf) >= bws.size {
+		bws.signal()
+	}
+
+	bws.lock.Unlock()
+	return len(p), nil
+}
+
+// Sync flushes the buffer and then syncs the underlying WriteSyncer.
+func (bws *BufferedWriteSyncer) Sync() error {
+	err := bws.flush(false)
+	if syncErr := bws.ws.Sync(); err == nil {
+		err = syncErr
+	}
+
+	return err
+}
+
+// Dropped returns the number of writes discarded because the buffer was full.
+func (bws *BufferedWriteSyncer) Dropped() uint64 {
+	return bws.dropped.Load()
+}
+
+// Stop stops the background goroutine and then flushes the buffer.  Subsequent writes go
+// directly to the underlying WriteSyncer.  This method is idempotent.
+func (bws *BufferedWriteSyncer) Stop() {
+	bws.stopOnce.Do(func() {
+		close(bws.done)
+		<-bws.finished
+		bws.flush(true)
+	})
+}
@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// testWriteSyncer is a zapcore.WriteSyncer that records what it is given.
+type testWriteSyncer struct {
+	lock   sync.Mutex
+	buf    bytes.Buffer
+	writes int
+	syncs  int
+
+	// gate, if set, must be received from before each write completes
+	gate chan struct{}
+}
+
+var _ zapcore.WriteSyncer = (*testWriteSyncer)(nil)
+
+func (tws *testWriteSyncer) Write(p []byte) (int, error) {
+	if tws.gate != nil {
+		<-tws.gate
+	}
+
+	tws.lock.Lock()
+	defer tws.lock.Unlock()
+	tws.writes++
+	return tws.buf.Write(p)
+}
+
+func (tws *testWriteSyncer) Sync() error {
+	tws.lock.Lock()
+	defer tws.lock.Unlock()
+	tws.syncs++
+	return nil
+}
+
+func (tws *testWriteSyncer) String() string {
+	tws.lock.Lock()
+	defer tws.lock.Unlock()
+	return tws.buf.String()
+}
+
+func testBufferedWriteSyncerSync(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		tws     = new(testWriteSyncer)
+		bws     = NewBufferedWriteSyncer(tws, BufferingConfig{FlushInterval: time.Hour})
+	)
+
+	defer bws.Stop()
+	bws.Write([]byte("first\n"))
+	bws.Write([]byte("second\n"))
+	assert.Empty(tws.String())
+
+	require.NoError(bws.Sync())
+	assert.Equal("first\nsecond\n", tws.String())
+	assert.Equal(1, tws.writes)
+	assert.Equal(1, tws.syncs)
+}
+
+func testBufferedWriteSyncerInterval(t *testing.T) {
+	var (
+		tws = new(testWriteSyncer)
+		bws = NewBufferedWriteSyncer(tws, BufferingConfig{FlushInterval: 10 * time.Millisecond})
+	)
+
+	defer bws.Stop()
+	bws.Write([]byte("flushed by interval\n"))
+	assert.Eventually(
+		t,
+		func() bool { return tws.String() == "flushed by interval\n" },
+		time.Second,
+		5*time.Millisecond,
+	)
+}
+
+func testBufferedWriteSyncerBlock(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		tws    = &testWriteSyncer{gate: make(chan struct{})}
+		bws    = NewBufferedWriteSyncer(tws, BufferingConfig{Size: 8, FlushInterval: time.Hour})
+		done   = make(chan struct{})
+	)
+
+	go func() {
+		defer close(done)
+
+		// the first write fills the buffer, the second is taken by the flush, and
+		// the third has to wait for that flush to finish
+		for _, s := range []string{"12345678", "abcdefgh", "ABCDEFGH"} {
+			bws.Write([]byte(s))
+		}
+	}()
+
+	select {
+	case <-done:
+		assert.Fail("writes to a full buffer should block")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(tws.gate)
+	<-done
+	bws.Stop()
+	assert.Equal("12345678abcdefghABCDEFGH", tws.String())
+	assert.Zero(bws.Dropped())
+}
+
+func testBufferedWriteSyncerDrop(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		tws    = new(testWriteSyncer)
+		bws    = NewBufferedWriteSyncer(tws, BufferingConfig{Size: 8, FlushInterval: time.Hour, OnFull: BufferDrop})
+	)
+
+	n, err := bws.Write([]byte("1234"))
+	assert.Equal(4, n)
+	assert.NoError(err)
+
+	n, err = bws.Write([]byte("dropped"))
+	assert.Equal(7, n)
+	assert.NoError(err)
+	assert.Equal(uint64(1), bws.Dropped())
+
+	bws.Stop()
+	assert.Equal("1234", tws.String())
+}
+
+func testBufferedWriteSyncerStop(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		tws    = new(testWriteSyncer)
+		bws    = NewBufferedWriteSyncer(tws, BufferingConfig{FlushInterval: time.Hour})
+	)
+
+	bws.Write([]byte("buffered\n"))
+	bws.Stop()
+	assert.Equal("buffered\n", tws.String())
+
+	// stopping is idempotent, and writes afterward are not buffered
+	bws.Stop()
+	bws.Write([]byte("direct\n"))
+	assert.Equal("buffered\ndirect\n", tws.String())
+}
+
+func testBufferedWriteSyncerStopRacing(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		tws    = new(testWriteSyncer)
+		bws    = NewBufferedWriteSyncer(tws, BufferingConfig{Size: 64, FlushInterval: time.Hour})
+
+		expected strings.Builder
+		wg       sync.WaitGroup
+	)
+
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&expected, "write %d\n", i)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			bws.Write([]byte(fmt.Sprintf("write %d\n", i)))
+		}
+	}()
+
+	// writes racing with stop are neither lost nor written ahead of the buffered output
+	time.Sleep(time.Millisecond)
+	bws.Stop()
+	wg.Wait()
+	assert.Equal(expected.String(), tws.String())
+}
+
+func TestBufferedWriteSyncer(t *testing.T) {
+	t.Run("Sync", testBufferedWriteSyncerSync)
+	t.Run("Interval", testBufferedWriteSyncerInterval)
+	t.Run("Block", testBufferedWriteSyncerBlock)
+	t.Run("Drop", testBufferedWriteSyncerDrop)
+	t.Run("Stop", testBufferedWriteSyncerStop)
+	t.Run("StopRacing", testBufferedWriteSyncerStopRacing)
+}
+
+func testBufferingBuild(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		file    = filepath.Join(t.TempDir(), "buffered.json")
+	)
+
+	l, _, res, err := Config{
+		OutputPaths: []string{file},
+		Buffering: &BufferingConfig{
+			FlushInterval: time.Hour,
+		},
+	}.build()
+
+	require.NoError(err)
+	l.Info("buffered")
+
+	contents, err := os.ReadFile(file)
+	require.NoError(err)
+	assert.Empty(contents)
+
+	res.stop()
+	contents, err = os.ReadFile(file)
+	require.NoError(err)
+	assert.Contains(string(contents), "buffered")
+
+	l.Info("after stop")
+	contents, err = os.ReadFile(file)
+	require.NoError(err)
+	assert.Equal(2, strings.Count(string(contents), "\n"))
+}
+
+func testBufferingValidate(t *testing.T) {
+	assert.Equal(
+		t,
+		[]string{
+			"buffering.size",
+			"buffering.flushInterval",
+			"buffering.onFull",
+		},
+		validationPaths(t, Config{
+			Buffering: &BufferingConfig{
+				Size:          -1,
+				FlushInterval: -time.Second,
+				OnFull:        "explode",
+			},
+		}.Validate()),
+	)
+}
+
+func TestBuffering(t *testing.T) {
+	t.Run("Build", testBufferingBuild)
+	t.Run("Validate", testBufferingValidate)
+}
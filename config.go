@@ -8,6 +8,8 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -90,10 +92,23 @@ type EncoderConfig struct {
 	EncodeLevel string `json:"levelEncoder" yaml:"levelEncoder" mapstructure:"levelEncoder"`
 
 	// EncodeTime determines how timestamps are represented.  If unset, RFC3339TimeEncoder is used.
+	// In addition to the names zapcore understands, this field may hold a custom Go time layout
+	// prefixed with TimeLayoutPrefix, e.g. "layout:2006-01-02 15:04:05.000".
 	//
 	// See: https://pkg.go.dev/go.uber.org/zap/zapcore#RFC3339TimeEncoder
+	// See: ParseTimeEncoder
 	EncodeTime string `json:"timeEncoder" yaml:"timeEncoder" mapstructure:"timeEncoder"`
 
+	// TimeZone is the optional IANA time zone name, such as "America/New_York", that
+	// timestamps are converted to before they are encoded.  If unset, timestamps are
+	// encoded in the local time zone.  This field cannot be combined with UTC.
+	//
+	// See: https://pkg.go.dev/time#LoadLocation
+	TimeZone string `json:"timeZone" yaml:"timeZone"`
+
+	// UTC forces timestamps to be encoded in UTC.  This field cannot be combined with TimeZone.
+	UTC bool `json:"utc" yaml:"utc"`
+
 	// EncodeDuration determines how time durations are represented.  If unset,
 	// StringDurationEncoder is used.
 	//
@@ -177,12 +192,27 @@ func (ec EncoderConfig) NewZapcoreEncoderConfig() (zec zapcore.EncoderConfig, er
 
 	if err == nil {
 		if len(ec.EncodeTime) > 0 {
-			err = zec.EncodeTime.UnmarshalText([]byte(ec.EncodeTime))
+			zec.EncodeTime, err = ParseTimeEncoder(ec.EncodeTime)
 		} else {
 			zec.EncodeTime = zapcore.RFC3339TimeEncoder
 		}
 	}
 
+	if err == nil {
+		var loc *time.Location
+		switch {
+		case ec.UTC:
+			loc = time.UTC
+
+		case len(ec.TimeZone) > 0:
+			loc, err = time.LoadLocation(ec.TimeZone)
+		}
+
+		if loc != nil {
+			zec.EncodeTime = timeEncoderIn(zec.EncodeTime, loc)
+		}
+	}
+
 	if err == nil {
 		if len(ec.EncodeDuration) > 0 {
 			err = zec.EncodeDuration.UnmarshalText([]byte(ec.EncodeDuration))
@@ -222,10 +252,36 @@ func (ec EncoderConfig) NewZapcoreEncoderConfig() (zec zapcore.EncoderConfig, er
 //
 // See: https://pkg.go.dev/go.uber.org/zap?tab=doc#Config.Build
 type Config struct {
+	// Preset is the optional name of a baseline configuration, such as PresetProduction or
+	// PresetDevelopment, that the other fields of this Config override.  If unset, no preset
+	// is used and the defaults described by each field apply.
+	//
+	// See: Config.ApplyPreset
+	Preset string `json:"preset,omitempty" yaml:"preset,omitempty"`
+
+	// Explicit lists the paths, as used by Merge, of the fields of this Config that are set
+	// even when they hold zero values.  When this Config is merged onto another, such as its
+	// Preset, these fields override the other's values.  This is how a configuration file turns
+	// off a preset's boolean or removes its sampling, e.g. with "samplingConfig": null.
+	//
+	// Decoding a Config from JSON or YAML, or with DecodeHook, adds the path of every field that
+	// appears in the input, so this field rarely needs to be set directly.
+	Explicit []string `json:"explicit,omitempty" yaml:"explicit,omitempty"`
+
 	// Level is the log level, which is converted to a zap.AtomicLevel.  If unset,
 	// info level is assumed.
 	Level string `json:"level" yaml:"level"`
 
+	// Levels are optional minimum log levels keyed by logger name prefix.  A prefix applies
+	// to loggers with that exact name and to any loggers named beneath it, e.g. "http"
+	// applies to "http" and "http.access" but not to "httpclient".  When several prefixes
+	// match a logger's name, the longest one is used.
+	//
+	// A matching entry takes the place of Level, and of the level of each of the Cores,
+	// for loggers created with zap.Logger.Named.  Note that when unmarshaling from
+	// spf13/viper, all keys in this map will be lowercased.
+	Levels map[string]string `json:"levels,omitempty" yaml:"levels,omitempty"`
+
 	// Development corresponds to zap.Config.Development
 	Development bool `json:"development" yaml:"development"`
 
@@ -239,9 +295,26 @@ type Config struct {
 	// here because zap.SamplingConfig uses primitive types.
 	Sampling *zap.SamplingConfig `json:"samplingConfig" yaml:"samplingConfig"`
 
+	// SamplingRules are optional sampling rules for particular levels or messages.  Each entry
+	// is sampled by the first rule that matches it.  Entries that match no rule are sampled
+	// according to Sampling, if it is set.
+	SamplingRules []SamplingRule `json:"samplingRules,omitempty" yaml:"samplingRules,omitempty"`
+
+	// SamplingSummary is the optional interval at which an info entry is logged with the number
+	// of entries kept and dropped by sampling during that interval.  The summary is logged
+	// whatever the logger's level.  If unset, or if there is no sampling, no summary is logged.  The summaries continue until the logger is closed, as
+	// described by BuildWithCloser.
+	SamplingSummary time.Duration `json:"samplingSummary,omitempty" yaml:"samplingSummary,omitempty"`
+
+	// SamplingCounter is an optional counter that receives every sampling decision made by
+	// Sampling and SamplingRules, e.g. for exposing metrics.  This counter is in addition to
+	// any Sampling.Hook.
+	SamplingCounter *SamplingCounter `json:"-" yaml:"-"`
+
 	// Encoding corresponds to zap.Config.Encoding.  If this is unset, and if Development
-	// is false, "json" is used.  "console" is the other built-in value for this field,
-	// and other encodings can be registered via the zap package.
+	// is false, "json" is used.  "console" is the other built-in value for this field.
+	// This package also registers LogfmtEncoding, and other encodings can be registered
+	// via the zap package.
 	//
 	// See: https://pkg.go.dev/go.uber.org/zap#RegisterEncoder
 	Encoding string `json:"encoding" yaml:"encoding"`
@@ -294,6 +367,29 @@ type Config struct {
 	// Rotation describes the set of log file rotation options.  This field is optional,
 	// and if unset log files are not rotated.
 	Rotation *Rotation `json:"rotation,omitempty" yaml:"rotation,omitempty"`
+
+	// Cores describes additional zapcore.Core instances, each with its own level, encoding,
+	// and output paths.  All cores, including the one described by the top-level fields
+	// of this Config, are combined with zapcore.NewTee.
+	//
+	// When Cores is set, the top-level core is only built if OutputPaths is nonempty.
+	// This allows a configuration to be expressed entirely in terms of Cores.
+	Cores []CoreConfig `json:"cores,omitempty" yaml:"cores,omitempty"`
+
+	// Redact describes the logging keys whose values are masked, hashed, or dropped before
+	// encoding.  Redaction applies to the top-level core and to each of the Cores.  This field
+	// is optional, and if unset no fields are redacted.
+	Redact *RedactConfig `json:"redact,omitempty" yaml:"redact,omitempty"`
+
+	// Dedupe describes how duplicate log entries are folded into a single summary entry with
+	// a repeat count.  Like Redact, this applies to the top-level core and to each of the Cores.
+	// This field is optional, and if unset every entry is written.
+	Dedupe *DedupeConfig `json:"dedupe,omitempty" yaml:"dedupe,omitempty"`
+
+	// Buffering describes how output is buffered and written asynchronously.  Like Redact, this
+	// applies to the top-level core and to each of the Cores, each of which gets its own buffer.
+	// This field is optional, and if unset output is written synchronously.
+	Buffering *BufferingConfig `json:"buffering,omitempty" yaml:"buffering,omitempty"`
 }
 
 func applyConfigDefaults(zc *zap.Config) {
@@ -348,7 +444,9 @@ func ensureExists(path string, perms fs.FileMode) (err error) {
 	default:
 		var url *url.URL
 		url, err = url.Parse(path)
-		if err == nil {
+
+		// network URLs, including syslog, and memory and tail URLs are never created here
+		if err == nil && !isNetworkScheme(url.Scheme) && !isEntryScheme(url.Scheme) {
 			f, err = os.OpenFile(url.Path, os.O_CREATE|os.O_WRONLY, perms)
 		}
 	}
@@ -356,6 +454,41 @@ func ensureExists(path string, perms fs.FileMode) (err error) {
 	return
 }
 
+// mapping returns the path expansion strategy for this Config, or nil if
+// path expansion is disabled.
+func (c Config) mapping() func(string) string {
+	switch {
+	case c.DisablePathExpansion:
+		return nil
+
+	case c.Mapping != nil:
+		return c.Mapping
+
+	default:
+		return os.Getenv
+	}
+}
+
+// preparePaths applies path expansion and the given log rotation options to a set of
+// output paths.  Any of the resulting paths that refer to files are then created with
+// the given permissions.
+func (c Config) preparePaths(r *Rotation, perms fs.FileMode, paths []string) (prepared []string, err error) {
+	pt := PathTransformer{
+		Rotation: r,
+		Mapping:  c.mapping(),
+	}
+
+	prepared, err = ApplyTransform(pt.Transform, paths...)
+
+	// Iterate over the transformed paths and ensure that any URIs that refer to
+	// files are created with relevant permissions.
+	for i := 0; err == nil && i < len(prepared); i++ {
+		err = ensureExists(prepared[i], perms)
+	}
+
+	return
+}
+
 // NewZapConfig creates a zap.Config enriched with features from these Options.
 // Primarily, this involves creating lumberjack URLs so that the registered sink
 // will create the appropriate infrastructure to do log file rotation.
@@ -364,7 +497,28 @@ func ensureExists(path string, perms fs.FileMode) (err error) {
 // will be created initially with the configured file permissions.  This allows
 // both zap's file sink and the custom lumberjack sink in this package to honor
 // custom permissions.
+//
+// Before anything else, this method applies any Preset and then uses Validate to check
+// the entire Config, including the Cores.  If there are any problems, the returned error
+// describes all of them.
 func (c Config) NewZapConfig() (zc zap.Config, err error) {
+	c, err = c.ApplyPreset()
+	if err == nil {
+		zc, err = c.zapConfig()
+	}
+
+	return
+}
+
+// zapConfig is the implementation of NewZapConfig for a Config whose Preset has already
+// been applied.
+func (c Config) zapConfig() (zc zap.Config, err error) {
+	var v validator
+	c.validate(&v)
+	if err = v.err(); err != nil {
+		return
+	}
+
 	zc = zap.Config{
 		Development:       c.Development,
 		DisableCaller:     c.DisableCaller,
@@ -397,34 +551,16 @@ func (c Config) NewZapConfig() (zc zap.Config, err error) {
 	}
 
 	var perms fs.FileMode
-	perms, err = ParsePermissions(c.Permissions)
-
 	if err == nil {
-		pt := PathTransformer{
-			Rotation: c.Rotation,
-		}
-
-		if !c.DisablePathExpansion {
-			pt.Mapping = c.Mapping
-			if pt.Mapping == nil {
-				pt.Mapping = os.Getenv
-			}
-		}
-
-		zc.OutputPaths, err = ApplyTransform(pt.Transform, zc.OutputPaths...)
-		if err == nil {
-			zc.ErrorOutputPaths, err = ApplyTransform(pt.Transform, zc.ErrorOutputPaths...)
-		}
+		perms, err = ParsePermissions(c.Permissions)
 	}
 
-	// Iterate over the transformed paths and ensure that any URIs that refer to
-	// files are created with relevant permissions.
-	for i := 0; err == nil && i < len(zc.OutputPaths); i++ {
-		err = ensureExists(zc.OutputPaths[i], perms)
+	if err == nil {
+		zc.OutputPaths, err = c.preparePaths(c.Rotation, perms, zc.OutputPaths)
 	}
 
-	for i := 0; err == nil && i < len(zc.ErrorOutputPaths); i++ {
-		err = ensureExists(zc.ErrorOutputPaths[i], perms)
+	if err == nil {
+		zc.ErrorOutputPaths, err = c.preparePaths(c.Rotation, perms, zc.ErrorOutputPaths)
 	}
 
 	if err == nil {
@@ -435,12 +571,74 @@ func (c Config) NewZapConfig() (zc zap.Config, err error) {
 }
 
 // Build behaves similarly to zap.Config.Build.  It uses the configuration created
-// by NewZapConfig to build the root logger.
+// by NewZapConfig to build the root logger, teeing in any additional Cores.
+//
+// Like zap's, the logger built by this method is meant to last for the life of the process.
+// Any background work, such as SamplingSummary, Buffering, or Dedupe, is never stopped and
+// the output sinks are never closed.  Use BuildWithCloser for a logger that can be released.
 func (c Config) Build(opts ...zap.Option) (l *zap.Logger, err error) {
-	var zc zap.Config
-	zc, err = c.NewZapConfig()
+	l, _, err = c.BuildWithLevels(opts...)
+	return
+}
+
+// BuildWithLevels is like Build, but also returns a LevelControl that can change
+// the logger's levels at runtime.  The global level of the LevelControl is shared
+// with every one of the Cores that does not configure its own level.
+func (c Config) BuildWithLevels(opts ...zap.Option) (l *zap.Logger, lc *LevelControl, err error) {
+	l, lc, _, err = c.build(opts...)
+	return
+}
+
+// BuildWithCloser is like BuildWithLevels, but also returns a function that releases the
+// logger.  The closer halts all background work, flushing any buffered output, and then
+// closes the output sinks.  The logger must not be used after it is closed.  The closer
+// is idempotent.
+func (c Config) BuildWithCloser(opts ...zap.Option) (l *zap.Logger, lc *LevelControl, closer func(), err error) {
+	var res *coreResources
+	l, lc, res, err = c.build(opts...)
+	if err == nil {
+		var once sync.Once
+		closer = func() {
+			once.Do(res.close)
+		}
+	}
+
+	return
+}
+
+// build is the implementation of BuildWithLevels.  It also returns the resources of the
+// logger, which can halt background work such as buffering and rotate the logger's files.
+func (c Config) build(opts ...zap.Option) (l *zap.Logger, lc *LevelControl, res *coreResources, err error) {
+	var (
+		zc    zap.Config
+		names map[string]zapcore.Level
+		core  zapcore.Core
+	)
+
+	c, err = c.ApplyPreset()
+	if err == nil {
+		zc, err = c.zapConfig()
+	}
+
+	if err == nil {
+		names, err = parseNameLevels(c.Levels)
+	}
+
 	if err == nil {
-		l, err = zc.Build(opts...)
+		lc = NewLevelControl(zc.Level, names)
+		core, res, err = c.newCore(zc, lc)
+	}
+
+	if err == nil {
+		l, err = newLogger(zc, core, opts...)
+		if err != nil {
+			res.close()
+		}
+	}
+
+	if err != nil {
+		lc = nil
+		res = nil
 	}
 
 	return
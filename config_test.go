@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
@@ -150,6 +151,75 @@ func (suite *EncoderConfigSuite) TestDisableDefaultKeys() {
 	suite.NotNil(zec.EncodeName)
 }
 
+// encodeTime runs a time through the EncodeTime function of a zapcore.EncoderConfig.
+func (suite *EncoderConfigSuite) encodeTime(zec zapcore.EncoderConfig, t time.Time) string {
+	var output bytes.Buffer
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zec),
+		zapcore.AddSync(&output),
+		zapcore.DebugLevel,
+	)
+
+	suite.Require().NoError(core.Write(zapcore.Entry{Time: t}, nil))
+	return output.String()
+}
+
+func (suite *EncoderConfigSuite) TestTimeLayout() {
+	var (
+		// 03:04 in New York is 08:04 UTC
+		newYork, err = time.LoadLocation("America/New_York")
+		t            = time.Date(2021, time.March, 4, 3, 4, 5, 6000000, newYork)
+	)
+
+	suite.Require().NoError(err)
+
+	testData := []struct {
+		ec       EncoderConfig
+		expected string
+	}{
+		{
+			ec: EncoderConfig{
+				EncodeTime: "layout:2006-01-02 15:04:05.000",
+			},
+			expected: `"ts":"2021-03-04 03:04:05.006"`,
+		},
+		{
+			ec: EncoderConfig{
+				EncodeTime: "layout:2006-01-02 15:04:05.000",
+				UTC:        true,
+			},
+			expected: `"ts":"2021-03-04 08:04:05.006"`,
+		},
+		{
+			ec: EncoderConfig{
+				EncodeTime: "layout:15:04 MST",
+				TimeZone:   "Asia/Tokyo",
+			},
+			expected: `"ts":"17:04 JST"`,
+		},
+		{
+			ec: EncoderConfig{
+				EncodeTime: "RFC3339",
+				UTC:        true,
+			},
+			expected: `"ts":"2021-03-04T08:04:05Z"`,
+		},
+	}
+
+	for _, record := range testData {
+		suite.Run(record.ec.EncodeTime, func() {
+			zec, err := record.ec.NewZapcoreEncoderConfig()
+			suite.Require().NoError(err)
+			suite.Contains(suite.encodeTime(zec, t), record.expected)
+		})
+	}
+}
+
+func (suite *EncoderConfigSuite) TestInvalidTimeZone() {
+	_, err := EncoderConfig{TimeZone: "Not/A_Zone"}.NewZapcoreEncoderConfig()
+	suite.Error(err)
+}
+
 func TestEncoderConfig(t *testing.T) {
 	suite.Run(t, new(EncoderConfigSuite))
 }
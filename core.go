@@ -0,0 +1,444 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// writerScheme is the internal sink scheme used to hand an already opened
+// zapcore.WriteSyncer to zap.Config.Build.  zap does not export its encoder
+// registry, so building through a zap.Config is the only way to honor encodings
+// registered with zap.RegisterEncoder.
+const writerScheme = "sallust-writer"
+
+var (
+	writerID atomic.Uint64
+	writers  sync.Map
+)
+
+func init() {
+	registerSink(writerScheme, newWriterSink)
+}
+
+// writerSink adapts a zapcore.WriteSyncer to a zap.Sink.  Closing the sink is
+// a nop, as the WriteSyncer is owned by whatever code opened it.
+type writerSink struct {
+	zapcore.WriteSyncer
+}
+
+func (ws writerSink) Close() error {
+	return nil
+}
+
+// newWriterSink is the sink factory for writerScheme.  Each registered writer
+// can be used exactly once.
+func newWriterSink(u *url.URL) (zap.Sink, error) {
+	if ws, ok := writers.LoadAndDelete(u.Opaque); ok {
+		return writerSink{WriteSyncer: ws.(zapcore.WriteSyncer)}, nil
+	}
+
+	return nil, fmt.Errorf("No writer registered for [%s]", u) // nolint:staticcheck
+}
+
+// newIOCore creates a zapcore.Core that uses the encoding, encoder configuration, and level
+// of the given zap.Config but writes to the supplied WriteSyncer.  All other fields
+// of the zap.Config are ignored.
+func newIOCore(zc zap.Config, ws zapcore.WriteSyncer) (zapcore.Core, error) {
+	if err := sinkError(writerScheme); err != nil {
+		return nil, err
+	}
+
+	id := strconv.FormatUint(writerID.Add(1), 10)
+	writers.Store(id, ws)
+	defer writers.Delete(id)
+
+	zc = zap.Config{
+		Level:         zc.Level,
+		Encoding:      zc.Encoding,
+		EncoderConfig: zc.EncoderConfig,
+		OutputPaths:   []string{writerScheme + ":" + id},
+	}
+
+	l, err := zc.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return l.Core(), nil
+}
+
+// fsyncCore syncs the underlying core after writing each entry at or above its level.
+// This is how lumberjack URLs with an fsync level flush important entries right away.
+type fsyncCore struct {
+	zapcore.Core
+	level zapcore.Level
+}
+
+func (fc fsyncCore) With(fields []zapcore.Field) zapcore.Core {
+	return fsyncCore{
+		Core:  fc.Core.With(fields),
+		level: fc.level,
+	}
+}
+
+func (fc fsyncCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if fc.Enabled(ent.Level) {
+		return ce.AddCore(ent, fc)
+	}
+
+	return ce
+}
+
+// Write writes the entry and then, if the entry is at or above the level, syncs
+// the core.  As with zapcore's own sync of fatal entries, errors from the sync are
+// ignored, since some outputs such as pipes cannot be synced.
+func (fc fsyncCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	err := fc.Core.Write(ent, fields)
+	if err == nil && ent.Level >= fc.level {
+		_ = fc.Core.Sync()
+	}
+
+	return err
+}
+
+// coreResources tracks the background work started and the output sinks opened for
+// the cores of a logger.
+type coreResources struct {
+	stops    []func()
+	closers  []func()
+	rotaters []Rotater
+}
+
+// onStop registers a function that halts background work.  The function must be idempotent.
+func (cr *coreResources) onStop(f func()) {
+	cr.stops = append(cr.stops, f)
+}
+
+// onClose registers a function that releases output sinks.
+func (cr *coreResources) onClose(f func()) {
+	cr.closers = append(cr.closers, f)
+}
+
+// onRotate registers an output sink that rotates or reopens its file.
+func (cr *coreResources) onRotate(r Rotater) {
+	cr.rotaters = append(cr.rotaters, r)
+}
+
+// rotate rotates every registered sink, even if some of them fail.  All the errors
+// are returned together.  Lumberjack sinks that share a file, such as those for two
+// output paths that name the same file, rotate that file only once.
+func (cr *coreResources) rotate() error {
+	var (
+		errs    []error
+		rotated = make(map[*lumberjackFile]bool)
+	)
+
+	for _, r := range cr.rotaters {
+		if lj, ok := r.(Lumberjack); ok && lj.ref != nil {
+			if rotated[lj.ref.file] {
+				continue
+			}
+
+			rotated[lj.ref.file] = true
+		}
+
+		if err := r.Rotate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// stop halts all background work, such as buffering and sampling summaries, in the reverse
+// of the order it was started.  Buffered output is flushed, and the sinks remain open.
+func (cr *coreResources) stop() {
+	for i := len(cr.stops) - 1; i >= 0; i-- {
+		cr.stops[i]()
+	}
+}
+
+// close halts all background work and then releases all the output sinks.
+func (cr *coreResources) close() {
+	cr.stop()
+	for _, f := range cr.closers {
+		f()
+	}
+}
+
+// openCore opens the output paths of the given zap.Config and creates a zapcore.Core
+// that writes to them.  The core's level is taken from the zap.Config, and any per-name
+// levels are taken from the LevelControl.  If buffering is supplied, the output paths other
+// than syslog, memory, and tail URLs are buffered.  Plain files are created with the given
+// permissions.  The decorate function is applied to the core beneath the level checks.
+// Anything started or opened for the core is registered with the coreResources.
+func openCore(zc zap.Config, perms fs.FileMode, control *LevelControl, buffering *BufferingConfig, decorate coreDecorator, res *coreResources) (core zapcore.Core, err error) {
+	level := zc.Level
+
+	// the levelCore does all the level checking, so the underlying
+	// core must allow everything through
+	zc.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
+
+	// syslog, memory, and tail sinks are opened directly, so that they
+	// receive the metadata of each entry
+	paths, syslogs, err := splitSyslogPaths(zc.OutputPaths)
+	for _, s := range syslogs {
+		res.onClose(func() { s.Close() })
+	}
+
+	var entrySinks []entrySink
+	if err == nil {
+		paths, entrySinks, err = splitEntryPaths(paths)
+	}
+
+	var cores []zapcore.Core
+	if err == nil && (len(paths) > 0 || len(syslogs)+len(entrySinks) == 0) {
+		var (
+			ws         zapcore.WriteSyncer
+			fsyncLevel *zapcore.Level
+		)
+
+		ws, fsyncLevel, err = openPaths(paths, perms, res)
+		if err == nil {
+			if buffering != nil {
+				bws := NewBufferedWriteSyncer(ws, *buffering)
+				res.onStop(bws.Stop)
+				ws = bws
+			}
+
+			core, err = newIOCore(zc, ws)
+			if err == nil && fsyncLevel != nil {
+				core = fsyncCore{Core: core, level: *fsyncLevel}
+			}
+
+			cores = append(cores, core)
+		}
+	}
+
+	for i := 0; err == nil && i < len(syslogs); i++ {
+		core, err = newSyslogCore(zc, syslogs[i])
+		cores = append(cores, core)
+	}
+
+	for i := 0; err == nil && i < len(entrySinks); i++ {
+		core, err = newEntryCore(zc, entrySinks[i])
+		cores = append(cores, core)
+	}
+
+	if err == nil {
+		var stop func()
+		core, stop = decorate(zapcore.NewTee(cores...))
+		res.onStop(stop)
+
+		core = levelCore{
+			Core:    core,
+			level:   level,
+			control: control,
+		}
+	}
+
+	return
+}
+
+// CoreConfig describes one of the additional zapcore.Core instances that are teed
+// together by Config.Build.  Each core has its own level, encoding, and outputs.
+type CoreConfig struct {
+	// Level is the log level for this core.  If unset, this core shares the
+	// level of the enclosing Config.
+	Level string `json:"level" yaml:"level"`
+
+	// Encoding is the zap encoding for this core.  If unset, "json" is used.
+	//
+	// See: https://pkg.go.dev/go.uber.org/zap#RegisterEncoder
+	Encoding string `json:"encoding" yaml:"encoding"`
+
+	// EncoderConfig describes how this core encodes log entries.
+	EncoderConfig EncoderConfig `json:"encoderConfig" yaml:"encoderConfig"`
+
+	// OutputPaths are the set of sinks for this core.  Path expansion honors the
+	// DisablePathExpansion and Mapping fields of the enclosing Config.
+	OutputPaths []string `json:"outputPaths" yaml:"outputPaths"`
+
+	// Rotation describes log file rotation for the files in OutputPaths.  This
+	// field is not inherited from the enclosing Config.  If unset, this core's
+	// log files are not rotated.
+	Rotation *Rotation `json:"rotation,omitempty" yaml:"rotation,omitempty"`
+
+	// Permissions is the optional nix-style file permissions to use when creating
+	// this core's log files.  This field is not inherited from the enclosing Config.
+	Permissions string `json:"permissions" yaml:"permissions"`
+}
+
+// newZapConfig converts this core configuration into a zap.Config that holds just
+// the level, encoding, encoder config, and output paths.  The level is the one to
+// share when this CoreConfig does not specify its own.
+func (cc CoreConfig) newZapConfig(c Config, level zap.AtomicLevel) (zc zap.Config, err error) {
+	zc = zap.Config{
+		Level:    level,
+		Encoding: cc.Encoding,
+	}
+
+	if len(zc.Encoding) == 0 {
+		zc.Encoding = "json"
+	}
+
+	if len(cc.Level) > 0 {
+		var l zapcore.Level
+		err = l.UnmarshalText([]byte(cc.Level))
+		if err == nil {
+			zc.Level = zap.NewAtomicLevelAt(l)
+		}
+	}
+
+	var perms fs.FileMode
+	if err == nil {
+		perms, err = ParsePermissions(cc.Permissions)
+	}
+
+	if err == nil {
+		zc.OutputPaths, err = c.preparePaths(cc.Rotation, perms, cc.OutputPaths)
+	}
+
+	if err == nil {
+		zc.EncoderConfig, err = cc.EncoderConfig.NewZapcoreEncoderConfig()
+	}
+
+	return
+}
+
+// coreDecorator applies optional behavior to each core built from a Config.  The returned
+// function halts any background work done by the decoration, and is called before the core's
+// sinks are closed.
+type coreDecorator func(zapcore.Core) (zapcore.Core, func())
+
+// decorator returns the coreDecorator for the redaction and duplicate suppression
+// described by this Config.  Duplicates are folded before fields are redacted, so
+// that summary entries are also redacted.
+func (c Config) decorator() coreDecorator {
+	r := newRedactor(c.Redact)
+	return func(core zapcore.Core) (zapcore.Core, func()) {
+		stop := func() {}
+		if r != nil {
+			core = redactCore{
+				Core:     core,
+				redactor: r,
+			}
+		}
+
+		if c.Dedupe != nil {
+			core, stop = newDedupeCore(core, *c.Dedupe)
+		}
+
+		return core, stop
+	}
+}
+
+// newCore builds the complete zapcore.Core described by this Config.  The zap.Config must
+// be the one produced by NewZapConfig, and the LevelControl must share its level.  The
+// returned coreResources stops the background work and closes the output sinks for the core.
+func (c Config) newCore(zc zap.Config, control *LevelControl) (core zapcore.Core, res *coreResources, err error) {
+	var (
+		cores    []zapcore.Core
+		decorate = c.decorator()
+	)
+
+	res = new(coreResources)
+	if len(c.Cores) == 0 || len(zc.OutputPaths) > 0 {
+		var perms fs.FileMode
+		perms, err = ParsePermissions(c.Permissions)
+		if err == nil {
+			core, err = openCore(zc, perms, control, c.Buffering, decorate, res)
+		}
+
+		if err == nil {
+			cores = append(cores, core)
+		}
+	}
+
+	for i := 0; err == nil && i < len(c.Cores); i++ {
+		var (
+			czc   zap.Config
+			perms fs.FileMode
+		)
+
+		czc, err = c.Cores[i].newZapConfig(c, zc.Level)
+		if err == nil {
+			perms, err = ParsePermissions(c.Cores[i].Permissions)
+		}
+
+		if err == nil {
+			core, err = openCore(czc, perms, control, c.Buffering, decorate, res)
+		}
+
+		if err == nil {
+			cores = append(cores, core)
+		} else {
+			err = fmt.Errorf("cores[%d]: %w", i, err)
+		}
+	}
+
+	if err != nil {
+		res.close()
+		return nil, nil, err
+	}
+
+	var stopSampling func()
+	core, stopSampling = c.newSampler(zc, zapcore.NewTee(cores...))
+
+	// registered last, so the sampling summary stops before anything else
+	res.onStop(stopSampling)
+	return
+}
+
+// initialFields converts a zap.Config's InitialFields into zap.Fields, sorted by key
+// in the same way as zap.Config.Build.
+func initialFields(m map[string]interface{}) []zap.Field {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	fields := make([]zap.Field, 0, len(keys))
+	for _, k := range keys {
+		fields = append(fields, zap.Any(k, m[k]))
+	}
+
+	return fields
+}
+
+// newLogger uses zap.Config.Build to create a logger that writes to the given core.
+// The zap.Config is still responsible for the logger-wide options, such as caller
+// and stacktrace behavior and the error output.  Any supplied options are applied
+// after the core has been replaced.
+func newLogger(zc zap.Config, core zapcore.Core, opts ...zap.Option) (*zap.Logger, error) {
+	merged := []zap.Option{
+		zap.WrapCore(func(zapcore.Core) zapcore.Core {
+			return core
+		}),
+	}
+
+	if len(zc.InitialFields) > 0 {
+		merged = append(merged, zap.Fields(initialFields(zc.InitialFields)...))
+	}
+
+	merged = append(merged, opts...)
+
+	// the output sinks and sampling are already part of the core
+	zc.OutputPaths = nil
+	zc.Sampling = nil
+	zc.InitialFields = nil
+	return zc.Build(merged...)
+}
@@ -0,0 +1,285 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type CoreSuite struct {
+	ZapcoreSuite
+}
+
+func (suite *CoreSuite) logFile(name string) string {
+	return filepath.Join(suite.logDirectory, name)
+}
+
+func (suite *CoreSuite) readLogFile(name string) string {
+	contents, err := os.ReadFile(suite.logFile(name))
+	suite.Require().NoError(err)
+	return string(contents)
+}
+
+func (suite *CoreSuite) TestTee() {
+	c := Config{
+		Level: "info",
+		Cores: []CoreConfig{
+			{
+				Encoding:    "json",
+				OutputPaths: []string{suite.logFile("info.json")},
+				Rotation: &Rotation{
+					MaxSize: 10,
+				},
+			},
+			{
+				Level:       "debug",
+				Encoding:    "console",
+				OutputPaths: []string{suite.logFile("debug.log")},
+				Permissions: "0600",
+			},
+		},
+		InitialFields: map[string]any{
+			"service": "test",
+		},
+	}
+
+	l, err := c.Build()
+	suite.Require().NoError(err)
+	suite.Require().NotNil(l)
+
+	l.Debug("debug message")
+	l.Info("info message")
+	suite.Require().NoError(l.Sync())
+
+	info := suite.readLogFile("info.json")
+	suite.NotContains(info, "debug message")
+	suite.Contains(info, `"msg":"info message"`)
+	suite.Contains(info, `"service":"test"`)
+
+	debug := suite.readLogFile("debug.log")
+	suite.Contains(debug, "debug message")
+	suite.Contains(debug, "info message")
+	suite.Contains(debug, "\t")
+	suite.assertLogFilePermissions("debug.log", 0600)
+}
+
+func (suite *CoreSuite) TestTopLevelCore() {
+	c := Config{
+		Level:       "warn",
+		OutputPaths: []string{suite.logFile("top.json")},
+		Cores: []CoreConfig{
+			{
+				OutputPaths: []string{suite.logFile("core.json")},
+			},
+		},
+	}
+
+	l, err := c.Build()
+	suite.Require().NoError(err)
+
+	l.Info("discarded")
+	l.Warn("warning message")
+	suite.Require().NoError(l.Sync())
+
+	for _, name := range []string{"top.json", "core.json"} {
+		contents := suite.readLogFile(name)
+		suite.NotContains(contents, "discarded")
+		suite.Equal(1, strings.Count(contents, "warning message"))
+	}
+}
+
+func (suite *CoreSuite) TestSampling() {
+	var sampled int
+	c := Config{
+		Cores: []CoreConfig{
+			{
+				OutputPaths: []string{suite.logFile("sampled.json")},
+			},
+		},
+		Sampling: &zap.SamplingConfig{
+			Initial:    1,
+			Thereafter: 1000,
+			Hook: func(_ zapcore.Entry, d zapcore.SamplingDecision) {
+				if d == zapcore.LogDropped {
+					sampled++
+				}
+			},
+		},
+	}
+
+	l, err := c.Build()
+	suite.Require().NoError(err)
+
+	for i := 0; i < 10; i++ {
+		l.Info("repeated")
+	}
+
+	suite.Require().NoError(l.Sync())
+	suite.Equal(1, strings.Count(suite.readLogFile("sampled.json"), "repeated"))
+	suite.Equal(9, sampled)
+}
+
+func (suite *CoreSuite) TestLevels() {
+	c := Config{
+		Level:       "info",
+		OutputPaths: []string{suite.logFile("top.json")},
+		Levels: map[string]string{
+			"fx":          "warn",
+			"http.access": "debug",
+		},
+		Cores: []CoreConfig{
+			{
+				Level:       "error",
+				OutputPaths: []string{suite.logFile("core.json")},
+			},
+		},
+	}
+
+	l, err := c.Build()
+	suite.Require().NoError(err)
+
+	l.Named("fx").Info("fx info")
+	l.Named("http").Named("access").Debug("access debug")
+	l.Named("http").Debug("http debug")
+	suite.Require().NoError(l.Sync())
+
+	for _, name := range []string{"top.json", "core.json"} {
+		contents := suite.readLogFile(name)
+		suite.NotContains(contents, "fx info")
+		suite.Contains(contents, "access debug")
+		suite.NotContains(contents, "http debug")
+	}
+
+	c.Levels["bad"] = "this is not a valid level"
+	_, err = c.Build()
+	suite.ErrorContains(err, "levels[bad]")
+}
+
+func (suite *CoreSuite) TestBuildWithLevels() {
+	c := Config{
+		Level: "info",
+		Levels: map[string]string{
+			"fx": "warn",
+		},
+		Cores: []CoreConfig{
+			{
+				OutputPaths: []string{suite.logFile("shared.json")},
+			},
+			{
+				Level:       "error",
+				OutputPaths: []string{suite.logFile("error.json")},
+			},
+		},
+	}
+
+	l, lc, err := c.BuildWithLevels()
+	suite.Require().NoError(err)
+	suite.Require().NotNil(l)
+	suite.Require().NotNil(lc)
+	suite.Equal(zapcore.InfoLevel, lc.Level())
+	suite.Equal(map[string]zapcore.Level{"fx": zapcore.WarnLevel}, lc.NameLevels())
+
+	l.Debug("before")
+	lc.SetLevel(zapcore.DebugLevel, 0)
+	lc.SetNameLevel("fx", zapcore.DebugLevel, 0)
+	l.Debug("after")
+	l.Named("fx").Debug("fx debug")
+	suite.Require().NoError(l.Sync())
+
+	shared := suite.readLogFile("shared.json")
+	suite.NotContains(shared, "before")
+	suite.Contains(shared, "after")
+	suite.Contains(shared, "fx debug")
+
+	// the error core has its own level, but per-name levels still apply
+	errorCore := suite.readLogFile("error.json")
+	suite.NotContains(errorCore, "after")
+	suite.Contains(errorCore, "fx debug")
+
+	_, lc, err = Config{Level: "this is not a valid level"}.BuildWithLevels()
+	suite.Error(err)
+	suite.Nil(lc)
+}
+
+func (suite *CoreSuite) TestInvalid() {
+	testData := []CoreConfig{
+		{
+			Level: "this is not a valid level",
+		},
+		{
+			Permissions: "this is not a valid permission",
+		},
+		{
+			Encoding: "this is not a valid encoding",
+		},
+		{
+			OutputPaths: []string{"nosuchscheme://foo"},
+		},
+	}
+
+	for _, cc := range testData {
+		c := Config{
+			Cores: []CoreConfig{cc},
+		}
+
+		l, err := c.Build()
+		suite.ErrorContains(err, "cores[0]")
+		suite.Nil(l)
+	}
+}
+
+// countingSyncer is a zapcore.WriteSyncer that counts its syncs.
+type countingSyncer struct {
+	zapcore.WriteSyncer
+	syncs int
+}
+
+func (cs *countingSyncer) Sync() error {
+	cs.syncs++
+	return cs.WriteSyncer.Sync()
+}
+
+func (suite *CoreSuite) TestFsyncCore() {
+	var (
+		cs   = &countingSyncer{WriteSyncer: zapcore.AddSync(new(strings.Builder))}
+		core = fsyncCore{
+			Core:  zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{}), cs, zapcore.DebugLevel),
+			level: zapcore.WarnLevel,
+		}
+
+		l = zap.New(core).With(zap.String("service", "test"))
+	)
+
+	l.Info("not synced")
+	suite.Zero(cs.syncs)
+
+	l.Warn("synced")
+	l.Error("synced")
+	suite.Equal(2, cs.syncs)
+}
+
+func (suite *CoreSuite) TestFsyncLevel() {
+	var (
+		path = suite.logFile("fsync.json")
+		u    = (&Rotation{FsyncLevel: "error"}).NewURL(path)
+	)
+
+	l, err := Config{OutputPaths: []string{u.String()}}.Build()
+	suite.Require().NoError(err)
+
+	l.Error("durable")
+	suite.Contains(suite.readLogFile("fsync.json"), "durable")
+	suite.NoError(l.Sync())
+}
+
+func TestCore(t *testing.T) {
+	suite.Run(t, new(CoreSuite))
+}
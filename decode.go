@@ -4,15 +4,31 @@
 package sallust
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+// TimeLayoutPrefix is the prefix for time encoder text that holds a custom Go time layout
+// rather than the name of a zapcore time encoder, e.g. "layout:2006-01-02 15:04:05.000".
+//
+// See: https://pkg.go.dev/time#pkg-constants
+const TimeLayoutPrefix = "layout:"
+
 var (
 	stringType = reflect.TypeOf("")
 
+	locationPtrType = reflect.TypeOf((*time.Location)(nil))
+
+	rotationType    = reflect.TypeOf(Rotation{})
+	rotationPtrType = reflect.PointerTo(rotationType)
+
+	configPtrType = reflect.PointerTo(configType)
+
 	levelType          = reflect.TypeOf(zapcore.Level(0))
 	levelPtrType       = reflect.PointerTo(levelType)
 	atomicLevelType    = reflect.TypeOf(zap.AtomicLevel{})
@@ -54,11 +70,35 @@ func decodeLevelEncoder(text string) (le zapcore.LevelEncoder, err error) {
 	return
 }
 
-func decodeTimeEncoder(text string) (te zapcore.TimeEncoder, err error) {
-	err = te.UnmarshalText([]byte(text))
+// ParseTimeEncoder converts text into a zapcore.TimeEncoder.  Text that begins with
+// TimeLayoutPrefix produces an encoder that formats timestamps with the remainder of the
+// text as a Go time layout.  Any other text is passed to zapcore.TimeEncoder.UnmarshalText.
+func ParseTimeEncoder(text string) (te zapcore.TimeEncoder, err error) {
+	if layout, ok := strings.CutPrefix(text, TimeLayoutPrefix); ok {
+		te = zapcore.TimeEncoderOfLayout(layout)
+	} else {
+		err = te.UnmarshalText([]byte(text))
+	}
+
 	return
 }
 
+// timeEncoderIn decorates a zapcore.TimeEncoder so that timestamps are converted
+// to the given location before they are encoded.
+func timeEncoderIn(te zapcore.TimeEncoder, loc *time.Location) zapcore.TimeEncoder {
+	return func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+		te(t.In(loc), enc)
+	}
+}
+
+func decodeTimeEncoder(text string) (te zapcore.TimeEncoder, err error) {
+	return ParseTimeEncoder(text)
+}
+
+func decodeLocation(text string) (*time.Location, error) {
+	return time.LoadLocation(text)
+}
+
 func decodeDurationEncoder(text string) (de zapcore.DurationEncoder, err error) {
 	err = de.UnmarshalText([]byte(text))
 	return
@@ -74,6 +114,32 @@ func decodeNameEncoder(text string) (ne zapcore.NameEncoder, err error) {
 	return
 }
 
+// decodeRotation converts the sizes and ages with units in a map of Rotation fields,
+// returning a new map for mapstructure to decode.
+func decodeRotation(src interface{}) (map[string]interface{}, error) {
+	fields, _ := asObject(src)
+	err := rotationUnits(fields)
+	return fields, err
+}
+
+// decodeConfig adds the path of every field in a map of Config fields to its explicit
+// paths, returning a new map for mapstructure to decode.
+func decodeConfig(src interface{}) map[string]interface{} {
+	fields, _ := asObject(src)
+
+	var explicit []string
+	if v, ok := fields["explicit"].([]interface{}); ok {
+		for _, path := range v {
+			explicit = append(explicit, fmt.Sprint(path))
+		}
+	} else if v, ok := fields["explicit"].([]string); ok {
+		explicit = v
+	}
+
+	fields["explicit"] = addPaths(explicit, objectPaths("", fields, configType))
+	return fields
+}
+
 // DecodeHook is an all-in-one mapstructure DecodeHookFunc that converts from
 // a string (typically unmarshaled in something like spf13/viper) into the appropriate
 // configuration field required by zapcore.
@@ -91,12 +157,32 @@ func decodeNameEncoder(text string) (ne zapcore.NameEncoder, err error) {
 //	zapcore.DurationEncoder
 //	zapcore.CallerEncoder
 //	zapcore.NameEncoder
+//	*time.Location
 //
-// The UnmarshalText method of the to type is used to do the conversion.
+// The UnmarshalText method of the to type is used to do the conversion, with two exceptions.
+// A zapcore.TimeEncoder is converted with ParseTimeEncoder, so custom layouts are allowed.
+// A *time.Location is converted with time.LoadLocation, which accepts IANA time zone names.
+//
+// In addition, a map may be converted to a Rotation or *Rotation.  The map's sizes and ages
+// with units, such as "250MiB" and "14d", are replaced with numbers of megabytes and days
+// as accepted by ParseMegabytes and ParseDays.  The resulting map is returned for
+// mapstructure to decode as usual.
+//
+// Likewise, when a map is converted to a Config or *Config, the path of every field in the
+// map is added to the map's explicit key, so that the decoded Config's Explicit field lists
+// the fields that were set.
 //
 // Any other from or to type will cause the function to do no conversion and
 // return the src as is with no error.
 func DecodeHook(from, to reflect.Type, src interface{}) (interface{}, error) {
+	if from != nil && from.Kind() == reflect.Map && (to == rotationType || to == rotationPtrType) {
+		return decodeRotation(src)
+	}
+
+	if from != nil && from.Kind() == reflect.Map && (to == configType || to == configPtrType) {
+		return decodeConfig(src), nil
+	}
+
 	if from != stringType {
 		return src, nil
 	}
@@ -131,6 +217,9 @@ func DecodeHook(from, to reflect.Type, src interface{}) (interface{}, error) {
 	case nameEncoderType:
 		return decodeNameEncoder(text)
 
+	case locationPtrType:
+		return decodeLocation(text)
+
 	default:
 		return src, nil
 	}
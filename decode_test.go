@@ -160,6 +160,60 @@ func testDecodeHookToTimeEncoder(t *testing.T) {
 	assert.Contains(output.String(), now.Format(time.RFC3339))
 }
 
+func testDecodeHookToTimeEncoderLayout(t *testing.T) {
+	var (
+		assert      = assert.New(t)
+		require     = require.New(t)
+		now         = time.Date(2021, time.March, 4, 5, 6, 7, 8000000, time.UTC)
+		result, err = DecodeHook(
+			reflect.TypeFor[string](),
+			reflect.TypeFor[zapcore.TimeEncoder](),
+			"layout:2006-01-02 15:04:05.000",
+		)
+	)
+
+	assert.NoError(err)
+
+	timeEncoder, ok := result.(zapcore.TimeEncoder)
+	require.True(ok)
+
+	var output bytes.Buffer
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+			TimeKey:    "ts",
+			EncodeTime: timeEncoder,
+		}),
+		zapcore.AddSync(&output),
+		zapcore.DebugLevel,
+	)
+
+	core.Write(zapcore.Entry{
+		Time: now,
+	}, nil)
+
+	assert.Contains(output.String(), `"ts":"2021-03-04 05:06:07.008"`)
+}
+
+func testDecodeHookToLocation(t *testing.T) {
+	assert := assert.New(t)
+	result, err := DecodeHook(
+		reflect.TypeFor[string](),
+		reflect.TypeFor[*time.Location](),
+		"UTC",
+	)
+
+	assert.Equal(time.UTC, result)
+	assert.NoError(err)
+
+	_, err = DecodeHook(
+		reflect.TypeFor[string](),
+		reflect.TypeFor[*time.Location](),
+		"Not/A_Zone",
+	)
+
+	assert.Error(err)
+}
+
 func testDecodeHookToDurationEncoder(t *testing.T) {
 	var (
 		assert      = assert.New(t)
@@ -266,6 +320,80 @@ func testDecodeHookToNameEncoder(t *testing.T) {
 	assert.Contains(output.String(), "foo.bar")
 }
 
+func testDecodeHookToRotation(t *testing.T) {
+	assert := assert.New(t)
+	result, err := DecodeHook(
+		reflect.TypeFor[map[string]interface{}](),
+		reflect.TypeFor[Rotation](),
+		map[string]interface{}{"maxSize": "250MiB", "maxage": "14d", "maxbackups": 3},
+	)
+
+	assert.Equal(map[string]interface{}{"maxSize": 250, "maxage": 14, "maxbackups": 3}, result)
+	assert.NoError(err)
+
+	// viper and YAML may produce maps with interface keys, and numbers are left alone
+	result, err = DecodeHook(
+		reflect.TypeFor[map[interface{}]interface{}](),
+		reflect.TypeFor[*Rotation](),
+		map[interface{}]interface{}{"maxsize": 100, "maxage": ""},
+	)
+
+	assert.Equal(map[string]interface{}{"maxsize": 100, "maxage": 0}, result)
+	assert.NoError(err)
+
+	_, err = DecodeHook(
+		reflect.TypeFor[map[string]interface{}](),
+		reflect.TypeFor[Rotation](),
+		map[string]interface{}{"maxsize": "lots"},
+	)
+
+	assert.Error(err)
+
+	_, err = DecodeHook(
+		reflect.TypeFor[map[string]interface{}](),
+		reflect.TypeFor[*Rotation](),
+		map[string]interface{}{"maxage": "forever"},
+	)
+
+	assert.Error(err)
+
+	// other maps are left alone
+	src := map[string]interface{}{"maxsize": "lots"}
+	result, err = DecodeHook(
+		reflect.TypeFor[map[string]interface{}](),
+		reflect.TypeFor[EncoderConfig](),
+		src,
+	)
+
+	assert.Equal(src, result)
+	assert.NoError(err)
+}
+
+func testDecodeHookToConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	// the keys may be in any case, as they are from spf13/viper
+	result, err := DecodeHook(
+		reflect.TypeFor[map[interface{}]interface{}](),
+		reflect.TypeFor[*Config](),
+		map[interface{}]interface{}{
+			"preset":            PresetProduction,
+			"samplingconfig":    nil,
+			"disablestacktrace": false,
+			"encoderconfig":     map[interface{}]interface{}{"utc": false},
+			"levels":            map[string]interface{}{"db": "debug"},
+			"explicit":          []interface{}{"development"},
+			"nosuchfield":       true,
+		},
+	)
+
+	assert.NoError(err)
+	assert.Equal(
+		[]string{"development", "disableStacktrace", "encoderConfig.utc", "explicit", "preset", "samplingConfig"},
+		result.(map[string]interface{})["explicit"],
+	)
+}
+
 func TestDecodeHook(t *testing.T) {
 	t.Run("NotAString", testDecodeHookNotAString)
 	t.Run("Unsupported", testDecodeHookUnsupported)
@@ -273,7 +401,11 @@ func TestDecodeHook(t *testing.T) {
 	t.Run("ToAtomicLevel", testDecodeHookToAtomicLevel)
 	t.Run("ToLevelEncoder", testDecodeHookToLevelEncoder)
 	t.Run("ToTimeEncoder", testDecodeHookToTimeEncoder)
+	t.Run("ToTimeEncoderLayout", testDecodeHookToTimeEncoderLayout)
+	t.Run("ToLocation", testDecodeHookToLocation)
 	t.Run("ToDurationEncoder", testDecodeHookToDurationEncoder)
 	t.Run("ToCallerEncoder", testDecodeHookToCallerEncoder)
 	t.Run("ToNameEncoder", testDecodeHookToNameEncoder)
+	t.Run("ToRotation", testDecodeHookToRotation)
+	t.Run("ToConfig", testDecodeHookToConfig)
 }
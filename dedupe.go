@@ -0,0 +1,288 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// DefaultDedupeWindow is the window used when DedupeConfig.Window is unset.
+	DefaultDedupeWindow = 10 * time.Second
+
+	// DefaultDedupeCountKey is the logging key used when DedupeConfig.CountKey is unset.
+	DefaultDedupeCountKey = "repeated"
+)
+
+// DedupeConfig describes how duplicate log entries are folded together.  Two entries are
+// duplicates when they have the same logger name, the same level, the same message, and the
+// same values for each of the selected Fields.  Other fields are ignored when comparing entries.
+//
+// The first entry is always written.  Any duplicates that follow within the Window are
+// suppressed and counted.  When the Window closes, a single summary entry is written with the
+// level, message, and fields of the last duplicate, along with the number of suppressed entries
+// under CountKey.  Any pending summaries are also written when the logger is synced or is
+// released with the closer from BuildWithCloser, so that they are not lost at shutdown.
+//
+// Each core built from a Config folds duplicates independently, after its own level checks.
+type DedupeConfig struct {
+	// Window is how long duplicates of an entry are suppressed.  If unset,
+	// DefaultDedupeWindow is used.
+	Window time.Duration `json:"window,omitempty" yaml:"window,omitempty"`
+
+	// Fields are the logging keys, in addition to the level and message, that must match for
+	// entries to be duplicates.  Fields added with With are included.  If unset, only the level
+	// and message are compared.
+	Fields []string `json:"fields,omitempty" yaml:"fields,omitempty"`
+
+	// CountKey is the logging key for the number of suppressed duplicates in a summary
+	// entry.  If unset, DefaultDedupeCountKey is used.
+	CountKey string `json:"countKey,omitempty" yaml:"countKey,omitempty"`
+}
+
+// dedupeWindow tracks the duplicates of one entry.
+type dedupeWindow struct {
+	start time.Time
+	count int
+	timer *time.Timer
+
+	// the last suppressed duplicate, which is used for the summary
+	core   zapcore.Core
+	ent    zapcore.Entry
+	fields []zapcore.Field
+}
+
+// dedupeState is shared by a dedupeCore and all the cores derived from it with With.
+type dedupeState struct {
+	window   time.Duration
+	selected map[string]bool
+	countKey string
+
+	lock      sync.Mutex
+	stopped   bool
+	lastSweep time.Time
+	windows   map[string]*dedupeWindow
+}
+
+func newDedupeState(dc DedupeConfig) *dedupeState {
+	ds := &dedupeState{
+		window:   dc.Window,
+		selected: make(map[string]bool, len(dc.Fields)),
+		countKey: dc.CountKey,
+		windows:  make(map[string]*dedupeWindow),
+	}
+
+	if ds.window <= 0 {
+		ds.window = DefaultDedupeWindow
+	}
+
+	if len(ds.countKey) == 0 {
+		ds.countKey = DefaultDedupeCountKey
+	}
+
+	for _, f := range dc.Fields {
+		ds.selected[f] = true
+	}
+
+	return ds
+}
+
+// key computes the value that identifies duplicates of an entry.
+func (ds *dedupeState) key(ent zapcore.Entry, context, fields []zapcore.Field) string {
+	var b strings.Builder
+	b.WriteString(strconv.Quote(ent.LoggerName))
+	b.WriteByte(' ')
+	b.WriteString(ent.Level.String())
+	b.WriteByte(' ')
+	b.WriteString(strconv.Quote(ent.Message))
+
+	if len(ds.selected) > 0 {
+		for _, set := range [][]zapcore.Field{context, fields} {
+			for _, f := range set {
+				if ds.selected[f.Key] {
+					fmt.Fprintf(&b, " %q=%q", f.Key, fmt.Sprint(fieldValue(f)))
+				}
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// summarize writes the summary entries for windows that had duplicates.  The lock must not
+// be held, so that slow writes do not hold up other entries and a core that logs through
+// this state cannot deadlock.
+func (ds *dedupeState) summarize(windows []*dedupeWindow) {
+	for _, w := range windows {
+		fields := append(w.fields, zap.Int(ds.countKey, w.count))
+		w.core.Write(w.ent, fields) // nolint:errcheck
+	}
+}
+
+// close ends the window for the given key and writes its summary.  This method is
+// invoked by the window's timer.
+func (ds *dedupeState) close(key string, w *dedupeWindow) {
+	ds.lock.Lock()
+	closed := !ds.stopped && ds.windows[key] == w
+	if closed {
+		delete(ds.windows, key)
+	}
+
+	ds.lock.Unlock()
+
+	if closed {
+		ds.summarize([]*dedupeWindow{w})
+	}
+}
+
+// sweep discards windows that have closed without any duplicates.  The lock must be held.
+func (ds *dedupeState) sweep(now time.Time) {
+	if now.Sub(ds.lastSweep) < ds.window {
+		return
+	}
+
+	ds.lastSweep = now
+	for key, w := range ds.windows {
+		if w.count == 0 && now.Sub(w.start) >= ds.window {
+			delete(ds.windows, key)
+		}
+	}
+}
+
+// suppress tests if an entry is a duplicate within an open window.  If it is, the entry
+// is recorded for the window's summary.
+func (ds *dedupeState) suppress(core zapcore.Core, ent zapcore.Entry, key string, fields []zapcore.Field) bool {
+	now := time.Now()
+
+	ds.lock.Lock()
+	defer ds.lock.Unlock()
+
+	if ds.stopped {
+		return false
+	}
+
+	ds.sweep(now)
+	w, ok := ds.windows[key]
+	if !ok || (w.count == 0 && now.Sub(w.start) >= ds.window) {
+		ds.windows[key] = &dedupeWindow{start: now}
+		return false
+	}
+
+	w.count++
+	w.core = core
+	w.ent = ent
+	w.fields = append([]zapcore.Field{}, fields...)
+	if w.timer == nil {
+		w.timer = time.AfterFunc(w.start.Add(ds.window).Sub(now), func() {
+			ds.close(key, w)
+		})
+	}
+
+	return true
+}
+
+// flush ends all the windows that have duplicates and writes their summaries.
+func (ds *dedupeState) flush() {
+	var closed []*dedupeWindow
+	ds.lock.Lock()
+	for key, w := range ds.windows {
+		if w.count > 0 {
+			w.timer.Stop()
+			delete(ds.windows, key)
+			closed = append(closed, w)
+		}
+	}
+
+	ds.lock.Unlock()
+	ds.summarize(closed)
+}
+
+// stop ends all windows, writing the summaries of those that have duplicates.  After this
+// method is called, no entries are suppressed.
+func (ds *dedupeState) stop() {
+	var closed []*dedupeWindow
+	ds.lock.Lock()
+	ds.stopped = true
+	for _, w := range ds.windows {
+		if w.timer != nil {
+			w.timer.Stop()
+		}
+
+		if w.count > 0 {
+			closed = append(closed, w)
+		}
+	}
+
+	ds.windows = make(map[string]*dedupeWindow)
+	ds.lock.Unlock()
+	ds.summarize(closed)
+}
+
+// dedupeCore decorates a zapcore.Core so that duplicate entries are folded together.
+type dedupeCore struct {
+	zapcore.Core
+
+	state *dedupeState
+
+	// context holds the fields added with With, for computing keys
+	context []zapcore.Field
+}
+
+var _ zapcore.Core = dedupeCore{}
+
+// newDedupeCore decorates a core with duplicate suppression.  The returned function
+// ends any pending windows, writing their summaries, and must be called before the
+// decorated core is closed.
+func newDedupeCore(core zapcore.Core, dc DedupeConfig) (zapcore.Core, func()) {
+	ds := newDedupeState(dc)
+	return dedupeCore{
+		Core:  core,
+		state: ds,
+	}, ds.stop
+}
+
+func (dc dedupeCore) With(fields []zapcore.Field) zapcore.Core {
+	var context []zapcore.Field
+	if len(dc.state.selected) > 0 {
+		context = make([]zapcore.Field, 0, len(dc.context)+len(fields))
+		context = append(context, dc.context...)
+		context = append(context, fields...)
+	}
+
+	return dedupeCore{
+		Core:    dc.Core.With(fields),
+		state:   dc.state,
+		context: context,
+	}
+}
+
+func (dc dedupeCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if dc.Enabled(ent.Level) {
+		return ce.AddCore(ent, dc)
+	}
+
+	return ce
+}
+
+func (dc dedupeCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	key := dc.state.key(ent, dc.context, fields)
+	if dc.state.suppress(dc.Core, ent, key, fields) {
+		return nil
+	}
+
+	return dc.Core.Write(ent, fields)
+}
+
+// Sync writes any pending summaries before syncing the decorated core.
+func (dc dedupeCore) Sync() error {
+	dc.state.flush()
+	return dc.Core.Sync()
+}
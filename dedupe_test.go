@@ -0,0 +1,222 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// testDedupeLogger builds a logger with duplicate suppression that writes JSON to a
+// temporary file.  The returned function returns each log entry written so far.
+func testDedupeLogger(t *testing.T, dc DedupeConfig) (*zap.Logger, func() []map[string]interface{}) {
+	file := filepath.Join(t.TempDir(), "dedupe.json")
+	l, err := Config{
+		OutputPaths: []string{file},
+		Dedupe:      &dc,
+	}.Build()
+
+	require.NoError(t, err)
+	return l, func() (entries []map[string]interface{}) {
+		contents, err := os.ReadFile(file)
+		require.NoError(t, err)
+
+		for _, line := range strings.Split(strings.TrimSpace(string(contents)), "\n") {
+			if len(line) > 0 {
+				var entry map[string]interface{}
+				require.NoError(t, json.Unmarshal([]byte(line), &entry))
+				entries = append(entries, entry)
+			}
+		}
+
+		return
+	}
+}
+
+func testDedupeWindow(t *testing.T) {
+	var (
+		assert     = assert.New(t)
+		l, entries = testDedupeLogger(t, DedupeConfig{Window: 100 * time.Millisecond})
+	)
+
+	for i := 0; i < 5; i++ {
+		l.Error("repeated", zap.Int("attempt", i))
+	}
+
+	l.Warn("repeated")
+	l.Error("different")
+
+	written := entries()
+	assert.Len(written, 3)
+
+	// the summary is written when the window closes
+	assert.Eventually(
+		func() bool { return len(entries()) == 4 },
+		time.Second,
+		10*time.Millisecond,
+	)
+
+	summary := entries()[3]
+	assert.Equal("repeated", summary["msg"])
+	assert.Equal("error", summary["level"])
+	assert.Equal(float64(4), summary[DefaultDedupeCountKey])
+	assert.Equal(float64(4), summary["attempt"])
+
+	// a new window starts after the summary
+	l.Error("repeated")
+	assert.Len(entries(), 5)
+}
+
+func testDedupeFields(t *testing.T) {
+	var (
+		assert     = assert.New(t)
+		l, entries = testDedupeLogger(t, DedupeConfig{
+			Window:   time.Hour,
+			Fields:   []string{"host", "code"},
+			CountKey: "count",
+		})
+	)
+
+	first := l.With(zap.String("host", "first"))
+	second := l.With(zap.String("host", "second"))
+	for i := 0; i < 3; i++ {
+		first.Error("failed", zap.Int("code", 500), zap.Int("attempt", i))
+		second.Error("failed", zap.Int("code", 500))
+		second.Error("failed", zap.Int("code", 503))
+	}
+
+	assert.Len(entries(), 3)
+
+	// syncing writes the pending summaries
+	require.NoError(t, l.Sync())
+	written := entries()
+	require.Len(t, written, 6)
+	for _, summary := range written[3:] {
+		assert.Equal(float64(2), summary["count"])
+	}
+}
+
+func testDedupeLoggerName(t *testing.T) {
+	var (
+		assert     = assert.New(t)
+		l, entries = testDedupeLogger(t, DedupeConfig{Window: time.Hour})
+	)
+
+	// the same message from differently named loggers is not a duplicate
+	l.Named("first").Error("failed")
+	l.Named("second").Error("failed")
+	l.Named("first").Error("failed")
+	assert.Len(entries(), 2)
+
+	require.NoError(t, l.Sync())
+	written := entries()
+	require.Len(t, written, 3)
+	assert.Equal(float64(1), written[2][DefaultDedupeCountKey])
+}
+
+// testReentrantCore is a core that calls a function from Write, standing in for
+// a core that logs through the dedupe core it is decorated by.
+type testReentrantCore struct {
+	zapcore.Core
+	f func()
+}
+
+func (trc testReentrantCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	trc.f()
+	return trc.Core.Write(ent, fields)
+}
+
+func testDedupeReentrant(t *testing.T) {
+	var (
+		assert       = assert.New(t)
+		observed, ol = observer.New(zapcore.DebugLevel)
+		reentrant    = &testReentrantCore{Core: observed}
+		core, stop   = newDedupeCore(reentrant, DedupeConfig{Window: 50 * time.Millisecond})
+		l            = zap.New(core)
+		done         = make(chan struct{})
+	)
+
+	defer stop()
+
+	// summaries are written without holding the lock
+	reentrant.f = func() { core.Sync() } // nolint:errcheck
+	go func() {
+		defer close(done)
+		l.Error("repeated")
+		l.Error("repeated")
+		l.Sync() // nolint:errcheck
+		l.Error("other")
+		l.Error("other")
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "deadlocked")
+	}
+
+	assert.Eventually(
+		func() bool { return ol.Len() == 4 },
+		time.Second,
+		10*time.Millisecond,
+	)
+}
+
+func testDedupeClose(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		file    = filepath.Join(t.TempDir(), "dedupe.json")
+	)
+
+	l, _, closer, err := Config{
+		OutputPaths: []string{file},
+		Dedupe:      &DedupeConfig{Window: time.Hour},
+	}.BuildWithCloser()
+
+	require.NoError(err)
+	l.Error("repeated")
+	l.Error("repeated")
+	l.Error("repeated")
+
+	// closing ends the pending window without a sync
+	closer()
+	contents, err := os.ReadFile(file)
+	require.NoError(err)
+
+	lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+	require.Len(lines, 2)
+
+	var summary map[string]interface{}
+	require.NoError(json.Unmarshal([]byte(lines[1]), &summary))
+	assert.Equal("repeated", summary["msg"])
+	assert.Equal(float64(2), summary[DefaultDedupeCountKey])
+}
+
+func testDedupeValidate(t *testing.T) {
+	assert.Equal(
+		t,
+		[]string{"dedupe.window"},
+		validationPaths(t, Config{Dedupe: &DedupeConfig{Window: -time.Second}}.Validate()),
+	)
+}
+
+func TestDedupe(t *testing.T) {
+	t.Run("Window", testDedupeWindow)
+	t.Run("Fields", testDedupeFields)
+	t.Run("LoggerName", testDedupeLoggerName)
+	t.Run("Reentrant", testDedupeReentrant)
+	t.Run("Close", testDedupeClose)
+	t.Run("Validate", testDedupeValidate)
+}
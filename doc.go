@@ -6,7 +6,12 @@ Package sallust adds a few useful features around the go.uber.org/zap package:
 
 - log rotation
 - path expansion using environment variables
+- overlaying configuration from environment variables
 - unmarshal-friendly configuration
+- buffered, asynchronous output
+- a logfmt encoding
+- syslog, TCP, UDP, and HTTP output
+- in-memory ring buffers and live streams of log entries
 - bootstrapping logging for a go.uber.org/fx application
 */
 package sallust
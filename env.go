@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultEnvPrefix is the prefix used by EnvOverlay when no Prefix is supplied.
+const DefaultEnvPrefix = "SALLUST_"
+
+// envVar describes one environment variable that can be overlaid onto a Config.
+// The name does not include the prefix.
+type envVar struct {
+	name  string
+	apply func(*Config, string) error
+}
+
+func envString(name string, field func(*Config) *string) envVar {
+	return envVar{
+		name: name,
+		apply: func(c *Config, value string) error {
+			*field(c) = value
+			return nil
+		},
+	}
+}
+
+func envBool(name string, field func(*Config) *bool) envVar {
+	return envVar{
+		name: name,
+		apply: func(c *Config, value string) (err error) {
+			var b bool
+			if len(value) > 0 {
+				b, err = strconv.ParseBool(value)
+			}
+
+			if err == nil {
+				*field(c) = b
+			}
+
+			return
+		},
+	}
+}
+
+func envInt(name string, field func(*Config) *int) envVar {
+	return envVar{
+		name: name,
+		apply: func(c *Config, value string) (err error) {
+			var i int
+			if len(value) > 0 {
+				i, err = strconv.Atoi(value)
+			}
+
+			if err == nil {
+				*field(c) = i
+			}
+
+			return
+		},
+	}
+}
+
+// envParsed applies a value to an int field with a parse function, such as ParseMegabytes.
+func envParsed(name string, parse func(string) (int, error), field func(*Config) *int) envVar {
+	return envVar{
+		name: name,
+		apply: func(c *Config, value string) (err error) {
+			var i int
+			if len(value) > 0 {
+				i, err = parse(value)
+			}
+
+			if err == nil {
+				*field(c) = i
+			}
+
+			return
+		},
+	}
+}
+
+// splitList splits a comma-separated value, trimming whitespace and dropping empty elements.
+func splitList(value string) (list []string) {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			list = append(list, v)
+		}
+	}
+
+	return
+}
+
+func envList(name string, field func(*Config) *[]string) envVar {
+	return envVar{
+		name: name,
+		apply: func(c *Config, value string) error {
+			*field(c) = splitList(value)
+			return nil
+		},
+	}
+}
+
+// envLevels parses a comma-separated list of name=level pairs into Config.Levels.
+func envLevels(name string) envVar {
+	return envVar{
+		name: name,
+		apply: func(c *Config, value string) error {
+			var levels map[string]string
+			for _, pair := range splitList(value) {
+				name, level, ok := strings.Cut(pair, "=")
+				if !ok {
+					return fmt.Errorf("Invalid level entry [%s]: expected name=level", pair) // nolint:staticcheck
+				}
+
+				if levels == nil {
+					levels = make(map[string]string)
+				}
+
+				levels[strings.TrimSpace(name)] = strings.TrimSpace(level)
+			}
+
+			c.Levels = levels
+			return nil
+		},
+	}
+}
+
+// rotation returns the Rotation of a Config, creating it if necessary.
+func rotation(c *Config) *Rotation {
+	if c.Rotation == nil {
+		c.Rotation = new(Rotation)
+	}
+
+	return c.Rotation
+}
+
+// envVars is the set of environment variables honored by EnvOverlay, in the order they are applied.
+var envVars = []envVar{
+	envString("PRESET", func(c *Config) *string { return &c.Preset }),
+	envString("LEVEL", func(c *Config) *string { return &c.Level }),
+	envLevels("LEVELS"),
+	envBool("DEVELOPMENT", func(c *Config) *bool { return &c.Development }),
+	envBool("DISABLE_CALLER", func(c *Config) *bool { return &c.DisableCaller }),
+	envBool("DISABLE_STACKTRACE", func(c *Config) *bool { return &c.DisableStacktrace }),
+	envString("ENCODING", func(c *Config) *string { return &c.Encoding }),
+	envList("OUTPUT_PATHS", func(c *Config) *[]string { return &c.OutputPaths }),
+	envList("ERROR_OUTPUT_PATHS", func(c *Config) *[]string { return &c.ErrorOutputPaths }),
+	envString("PERMISSIONS", func(c *Config) *string { return &c.Permissions }),
+
+	envParsed("ROTATION_MAX_SIZE", ParseMegabytes, func(c *Config) *int { return &rotation(c).MaxSize }),
+	envParsed("ROTATION_MAX_AGE", ParseDays, func(c *Config) *int { return &rotation(c).MaxAge }),
+	envInt("ROTATION_MAX_BACKUPS", func(c *Config) *int { return &rotation(c).MaxBackups }),
+	envBool("ROTATION_LOCAL_TIME", func(c *Config) *bool { return &rotation(c).LocalTime }),
+	envBool("ROTATION_COMPRESS", func(c *Config) *bool { return &rotation(c).Compress }),
+	envString("ROTATION_SCHEDULE", func(c *Config) *string { return &rotation(c).Schedule }),
+	envBool("ROTATION_FSYNC", func(c *Config) *bool { return &rotation(c).Fsync }),
+	envString("ROTATION_FSYNC_LEVEL", func(c *Config) *string { return &rotation(c).FsyncLevel }),
+
+	envBool("ENCODER_DISABLE_DEFAULT_KEYS", func(c *Config) *bool { return &c.EncoderConfig.DisableDefaultKeys }),
+	envString("ENCODER_MESSAGE_KEY", func(c *Config) *string { return &c.EncoderConfig.MessageKey }),
+	envString("ENCODER_LEVEL_KEY", func(c *Config) *string { return &c.EncoderConfig.LevelKey }),
+	envString("ENCODER_TIME_KEY", func(c *Config) *string { return &c.EncoderConfig.TimeKey }),
+	envString("ENCODER_NAME_KEY", func(c *Config) *string { return &c.EncoderConfig.NameKey }),
+	envString("ENCODER_CALLER_KEY", func(c *Config) *string { return &c.EncoderConfig.CallerKey }),
+	envString("ENCODER_FUNCTION_KEY", func(c *Config) *string { return &c.EncoderConfig.FunctionKey }),
+	envString("ENCODER_STACKTRACE_KEY", func(c *Config) *string { return &c.EncoderConfig.StacktraceKey }),
+	envString("ENCODER_LEVEL_ENCODER", func(c *Config) *string { return &c.EncoderConfig.EncodeLevel }),
+	envString("ENCODER_TIME_ENCODER", func(c *Config) *string { return &c.EncoderConfig.EncodeTime }),
+	envString("ENCODER_TIME_ZONE", func(c *Config) *string { return &c.EncoderConfig.TimeZone }),
+	envBool("ENCODER_UTC", func(c *Config) *bool { return &c.EncoderConfig.UTC }),
+	envString("ENCODER_DURATION_ENCODER", func(c *Config) *string { return &c.EncoderConfig.EncodeDuration }),
+	envString("ENCODER_CALLER_ENCODER", func(c *Config) *string { return &c.EncoderConfig.EncodeCaller }),
+	envString("ENCODER_NAME_ENCODER", func(c *Config) *string { return &c.EncoderConfig.EncodeName }),
+	envString("ENCODER_CONSOLE_SEPARATOR", func(c *Config) *string { return &c.EncoderConfig.ConsoleSeparator }),
+}
+
+// EnvOverlay overlays environment variables onto a Config.  Each variable is the Prefix
+// followed by one of the names below, e.g. SALLUST_LEVEL or SALLUST_ROTATION_MAX_SIZE.
+//
+//	PRESET, LEVEL, ENCODING, PERMISSIONS
+//	LEVELS                            comma-separated name=level pairs, e.g. "fx=warn,http=debug"
+//	DEVELOPMENT, DISABLE_CALLER, DISABLE_STACKTRACE
+//	OUTPUT_PATHS, ERROR_OUTPUT_PATHS  comma-separated paths
+//	ROTATION_MAX_SIZE, ROTATION_MAX_AGE, ROTATION_MAX_BACKUPS
+//	ROTATION_LOCAL_TIME, ROTATION_COMPRESS, ROTATION_SCHEDULE
+//	ROTATION_FSYNC, ROTATION_FSYNC_LEVEL
+//	ENCODER_DISABLE_DEFAULT_KEYS
+//	ENCODER_MESSAGE_KEY, ENCODER_LEVEL_KEY, ENCODER_TIME_KEY, ENCODER_NAME_KEY
+//	ENCODER_CALLER_KEY, ENCODER_FUNCTION_KEY, ENCODER_STACKTRACE_KEY
+//	ENCODER_LEVEL_ENCODER, ENCODER_TIME_ENCODER, ENCODER_TIME_ZONE, ENCODER_UTC
+//	ENCODER_DURATION_ENCODER, ENCODER_CALLER_ENCODER, ENCODER_NAME_ENCODER
+//	ENCODER_CONSOLE_SEPARATOR
+//
+// Precedence is simple:  a variable that is set, even to an empty string, replaces the
+// corresponding field of the Config.  A variable that is not set leaves the field alone.
+// An empty value resets the field to its zero value, so that SALLUST_OUTPUT_PATHS="" discards
+// output.  Lists and LEVELS replace the field entirely rather than adding to it.  Setting
+// any of the ROTATION_ variables creates a Rotation if the Config has none.  ROTATION_MAX_SIZE
+// and ROTATION_MAX_AGE may have units, as accepted by ParseMegabytes and ParseDays.
+//
+// The Cores of a Config are not affected by any environment variable.
+type EnvOverlay struct {
+	// Prefix is prepended to each variable name.  If unset, DefaultEnvPrefix is used.
+	Prefix string
+
+	// Lookup retrieves a variable's value and whether it is set.  If unset, os.LookupEnv is used.
+	Lookup func(string) (string, bool)
+}
+
+// Apply returns a copy of the given Config with the environment variables overlaid.
+// The original Config is not modified.  If any variable cannot be parsed, an error naming
+// each such variable is returned along with the unchanged Config.
+func (eo EnvOverlay) Apply(c Config) (Config, error) {
+	var (
+		prefix = eo.Prefix
+		lookup = eo.Lookup
+		errs   []error
+	)
+
+	if len(prefix) == 0 {
+		prefix = DefaultEnvPrefix
+	}
+
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+
+	overlaid := c
+	if c.Rotation != nil {
+		r := *c.Rotation
+		overlaid.Rotation = &r
+	}
+
+	for _, ev := range envVars {
+		name := prefix + ev.name
+		if value, ok := lookup(name); ok {
+			if err := ev.apply(&overlaid, value); err != nil {
+				errs = append(errs, fmt.Errorf("Invalid environment variable [%s]: %w", name, err)) // nolint:staticcheck
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return c, errors.Join(errs...)
+	}
+
+	return overlaid, nil
+}
+
+// ApplyEnv overlays the process environment onto a Config using DefaultEnvPrefix.
+// See EnvOverlay for the variables and precedence rules.
+func ApplyEnv(c Config) (Config, error) {
+	return EnvOverlay{}.Apply(c)
+}
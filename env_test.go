@@ -0,0 +1,249 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testLookup returns a lookup function backed by a map.
+func testLookup(env map[string]string) func(string) (string, bool) {
+	return func(name string) (value string, ok bool) {
+		value, ok = env[name]
+		return
+	}
+}
+
+func testEnvOverlayNoVariables(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		c       = Config{
+			Level:       "warn",
+			OutputPaths: []string{Stdout},
+		}
+	)
+
+	overlaid, err := EnvOverlay{Lookup: testLookup(nil)}.Apply(c)
+	require.NoError(err)
+	assert.Equal("warn", overlaid.Level)
+	assert.Equal([]string{Stdout}, overlaid.OutputPaths)
+	assert.Nil(overlaid.Rotation)
+}
+
+func testEnvOverlayAll(t *testing.T) {
+	var (
+		assert   = assert.New(t)
+		require  = require.New(t)
+		original = Config{
+			Level:       "warn",
+			Encoding:    "console",
+			OutputPaths: []string{Stdout},
+			Rotation: &Rotation{
+				MaxSize: 10,
+				MaxAge:  3,
+			},
+		}
+
+		env = map[string]string{
+			"SALLUST_PRESET":                       "production",
+			"SALLUST_LEVEL":                        "debug",
+			"SALLUST_LEVELS":                       "fx=warn, http.access=debug",
+			"SALLUST_DEVELOPMENT":                  "true",
+			"SALLUST_DISABLE_CALLER":               "1",
+			"SALLUST_DISABLE_STACKTRACE":           "true",
+			"SALLUST_ENCODING":                     "",
+			"SALLUST_OUTPUT_PATHS":                 "/var/log/app.log, stdout",
+			"SALLUST_ERROR_OUTPUT_PATHS":           "stderr",
+			"SALLUST_PERMISSIONS":                  "0600",
+			"SALLUST_ROTATION_MAX_SIZE":            "100",
+			"SALLUST_ROTATION_MAX_BACKUPS":         "5",
+			"SALLUST_ROTATION_LOCAL_TIME":          "true",
+			"SALLUST_ROTATION_COMPRESS":            "true",
+			"SALLUST_ROTATION_SCHEDULE":            "daily",
+			"SALLUST_ROTATION_FSYNC":               "true",
+			"SALLUST_ROTATION_FSYNC_LEVEL":         "error",
+			"SALLUST_ENCODER_DISABLE_DEFAULT_KEYS": "true",
+			"SALLUST_ENCODER_MESSAGE_KEY":          "message",
+			"SALLUST_ENCODER_LEVEL_KEY":            "lvl",
+			"SALLUST_ENCODER_TIME_KEY":             "time",
+			"SALLUST_ENCODER_NAME_KEY":             "logger",
+			"SALLUST_ENCODER_CALLER_KEY":           "caller",
+			"SALLUST_ENCODER_FUNCTION_KEY":         "func",
+			"SALLUST_ENCODER_STACKTRACE_KEY":       "stack",
+			"SALLUST_ENCODER_LEVEL_ENCODER":        "capital",
+			"SALLUST_ENCODER_TIME_ENCODER":         "layout:15:04:05",
+			"SALLUST_ENCODER_TIME_ZONE":            "America/New_York",
+			"SALLUST_ENCODER_DURATION_ENCODER":     "ms",
+			"SALLUST_ENCODER_CALLER_ENCODER":       "short",
+			"SALLUST_ENCODER_NAME_ENCODER":         "full",
+			"SALLUST_ENCODER_CONSOLE_SEPARATOR":    "|",
+		}
+	)
+
+	c, err := EnvOverlay{Lookup: testLookup(env)}.Apply(original)
+	require.NoError(err)
+
+	assert.Equal("production", c.Preset)
+	assert.Equal("debug", c.Level)
+	assert.Equal(map[string]string{"fx": "warn", "http.access": "debug"}, c.Levels)
+	assert.True(c.Development)
+	assert.True(c.DisableCaller)
+	assert.True(c.DisableStacktrace)
+	assert.Empty(c.Encoding)
+	assert.Equal([]string{"/var/log/app.log", Stdout}, c.OutputPaths)
+	assert.Equal([]string{Stderr}, c.ErrorOutputPaths)
+	assert.Equal("0600", c.Permissions)
+	assert.Equal(
+		&Rotation{
+			MaxSize:    100,
+			MaxAge:     3,
+			MaxBackups: 5,
+			LocalTime:  true,
+			Compress:   true,
+			Schedule:   "daily",
+			Fsync:      true,
+			FsyncLevel: "error",
+		},
+		c.Rotation,
+	)
+
+	assert.Equal(
+		EncoderConfig{
+			DisableDefaultKeys: true,
+			MessageKey:         "message",
+			LevelKey:           "lvl",
+			TimeKey:            "time",
+			NameKey:            "logger",
+			CallerKey:          "caller",
+			FunctionKey:        "func",
+			StacktraceKey:      "stack",
+			EncodeLevel:        "capital",
+			EncodeTime:         "layout:15:04:05",
+			TimeZone:           "America/New_York",
+			EncodeDuration:     "ms",
+			EncodeCaller:       "short",
+			EncodeName:         "full",
+			ConsoleSeparator:   "|",
+		},
+		c.EncoderConfig,
+	)
+
+	assert.NoError(c.Validate())
+
+	// the original must be untouched
+	assert.Equal("warn", original.Level)
+	assert.Equal(&Rotation{MaxSize: 10, MaxAge: 3}, original.Rotation)
+}
+
+func testEnvOverlayPrefix(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		env     = map[string]string{
+			"SALLUST_LEVEL":              "debug",
+			"MYAPP_LOG_LEVEL":            "error",
+			"MYAPP_LOG_ROTATION_MAX_AGE": "7",
+		}
+	)
+
+	c, err := EnvOverlay{Prefix: "MYAPP_LOG_", Lookup: testLookup(env)}.Apply(Config{})
+	require.NoError(err)
+	assert.Equal("error", c.Level)
+	assert.Equal(&Rotation{MaxAge: 7}, c.Rotation)
+}
+
+func testEnvOverlayUnits(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		env     = map[string]string{
+			"SALLUST_ROTATION_MAX_SIZE": "250MiB",
+			"SALLUST_ROTATION_MAX_AGE":  "14d",
+		}
+	)
+
+	c, err := EnvOverlay{Lookup: testLookup(env)}.Apply(Config{})
+	require.NoError(err)
+	assert.Equal(&Rotation{MaxSize: 250, MaxAge: 14}, c.Rotation)
+}
+
+func testEnvOverlayEmpty(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		env     = map[string]string{
+			"SALLUST_OUTPUT_PATHS":        "",
+			"SALLUST_DEVELOPMENT":         "",
+			"SALLUST_ROTATION_MAX_SIZE":   "",
+			"SALLUST_LEVELS":              "",
+			"SALLUST_ENCODER_MESSAGE_KEY": "",
+		}
+	)
+
+	c, err := EnvOverlay{Lookup: testLookup(env)}.Apply(Config{
+		Development: true,
+		OutputPaths: []string{Stdout},
+		Levels:      map[string]string{"fx": "warn"},
+		Rotation:    &Rotation{MaxSize: 10},
+		EncoderConfig: EncoderConfig{
+			MessageKey: "message",
+		},
+	})
+
+	require.NoError(err)
+	assert.False(c.Development)
+	assert.Empty(c.OutputPaths)
+	assert.Empty(c.Levels)
+	assert.Equal(&Rotation{}, c.Rotation)
+	assert.Empty(c.EncoderConfig.MessageKey)
+}
+
+func testEnvOverlayInvalid(t *testing.T) {
+	var (
+		assert   = assert.New(t)
+		original = Config{Level: "warn"}
+		env      = map[string]string{
+			"SALLUST_LEVEL":             "debug",
+			"SALLUST_DEVELOPMENT":       "not a bool",
+			"SALLUST_ROTATION_MAX_SIZE": "not an int",
+			"SALLUST_LEVELS":            "fx",
+		}
+	)
+
+	c, err := EnvOverlay{Lookup: testLookup(env)}.Apply(original)
+	assert.Error(err)
+	assert.ErrorContains(err, "SALLUST_DEVELOPMENT")
+	assert.ErrorContains(err, "SALLUST_ROTATION_MAX_SIZE")
+	assert.ErrorContains(err, "SALLUST_LEVELS")
+	assert.Equal(original, c)
+}
+
+func testApplyEnv(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	t.Setenv("SALLUST_LEVEL", "error")
+	t.Setenv("SALLUST_OUTPUT_PATHS", "stdout,stderr")
+
+	c, err := ApplyEnv(Config{Level: "info"})
+	require.NoError(err)
+	assert.Equal("error", c.Level)
+	assert.Equal([]string{Stdout, Stderr}, c.OutputPaths)
+}
+
+func TestEnvOverlay(t *testing.T) {
+	t.Run("NoVariables", testEnvOverlayNoVariables)
+	t.Run("All", testEnvOverlayAll)
+	t.Run("Prefix", testEnvOverlayPrefix)
+	t.Run("Empty", testEnvOverlayEmpty)
+	t.Run("Units", testEnvOverlayUnits)
+	t.Run("Invalid", testEnvOverlayInvalid)
+	t.Run("ApplyEnv", testApplyEnv)
+}
@@ -5,6 +5,8 @@ package sallust
 
 import (
 	"context"
+	"os"
+	"os/signal"
 
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxevent"
@@ -25,15 +27,40 @@ type LoggerIn struct {
 	Options []zap.Option `optional:"true"`
 }
 
+// loggerStopper halts any background work, such as buffering, for the logger created by
+// WithLogger.  The logger's sinks remain open.
+type loggerStopper func()
+
+// loggerRotater rotates the Lumberjack sinks and reopens the plain files of the logger
+// created by WithLogger.
+type loggerRotater func() error
+
+func (lr loggerRotater) Rotate() error {
+	return lr()
+}
+
+// shutdownIn describes the dependencies of SyncOnShutdown.
+type shutdownIn struct {
+	fx.In
+
+	Logger    *zap.Logger
+	Lifecycle fx.Lifecycle
+
+	// Stop is supplied by WithLogger.  It is optional so that SyncOnShutdown works
+	// with loggers provided by other means.
+	Stop loggerStopper `optional:"true"`
+}
+
 // WithLogger bootstraps a go.uber.org/zap logger together with an fxevent.Logger,
-// using the dependencies described in LoggerIn.
+// using the dependencies described in LoggerIn.  The logger's *LevelControl is also
+// provided as a component, and its files can be rotated with RotateOnSignal.
 //
 // If any zap.Options are supplied to this function, they take precedence over any
 // options injected via LoggerIn.
 func WithLogger(options ...zap.Option) fx.Option {
 	return fx.Options(
 		fx.Provide(
-			func(in LoggerIn) (*zap.Logger, error) {
+			func(in LoggerIn) (*zap.Logger, *LevelControl, loggerStopper, loggerRotater, error) {
 				merged := make([]zap.Option, 0, len(options)+len(in.Options))
 
 				// options passed to this function take preceence over options
@@ -41,7 +68,12 @@ func WithLogger(options ...zap.Option) fx.Option {
 				merged = append(merged, options...)
 				merged = append(merged, in.Options...)
 
-				return in.Config.Build(merged...)
+				l, lc, res, err := in.Config.build(merged...)
+				if err != nil {
+					return nil, nil, nil, nil, err
+				}
+
+				return l, lc, loggerStopper(res.stop), loggerRotater(res.rotate), nil
 			},
 		),
 		fx.WithLogger(
@@ -55,14 +87,21 @@ func WithLogger(options ...zap.Option) fx.Option {
 }
 
 // SyncOnShutdown adds an fx lifecycle hook that invokes Sync on the application's logger.
+// If the logger was created by WithLogger with Config.Buffering, any buffering is then stopped
+// so that all buffered output has been written once the hook returns.  Log entries written
+// after that go directly to the sinks.
+//
 // Generally, this option should be placed as an fx.Invoke last in the set of options.
 // That ensures that log entries from other lifecycle OnStop hooks are written to log sinks.
 func SyncOnShutdown() fx.Option {
 	return fx.Invoke(
-		func(logger *zap.Logger, lifecycle fx.Lifecycle) {
-			lifecycle.Append(fx.Hook{
+		func(in shutdownIn) {
+			in.Lifecycle.Append(fx.Hook{
 				OnStop: func(context.Context) error {
-					logger.Sync()
+					in.Logger.Sync()
+					if in.Stop != nil {
+						in.Stop()
+					}
 
 					// NOTE: do NOT return the error from Sync.
 					// A non-nil error may short-circuit app shutdown,
@@ -73,3 +112,58 @@ func SyncOnShutdown() fx.Option {
 		},
 	)
 }
+
+// rotateIn describes the dependencies of RotateOnSignal.
+type rotateIn struct {
+	fx.In
+
+	Logger    *zap.Logger
+	Lifecycle fx.Lifecycle
+	Rotate    loggerRotater
+}
+
+// RotateOnSignal adds fx lifecycle hooks that run a SignalRotater for the logger created
+// by WithLogger.  Whenever the process receives one of the given signals, or SIGHUP if none
+// are given, the logger's Lumberjack sinks are rotated and its plain files are reopened.
+// This allows external tools such as logrotate to move log files aside and then signal
+// the process.  Any rotation errors are logged.
+func RotateOnSignal(signals ...os.Signal) fx.Option {
+	return fx.Invoke(
+		func(in rotateIn) {
+			var (
+				sr = SignalRotater{
+					Rotater: in.Rotate,
+					Signals: signals,
+					OnError: func(err error) {
+						in.Logger.Error("unable to rotate log files", zap.Error(err))
+					},
+				}
+
+				cancel context.CancelFunc
+				done   = make(chan struct{})
+			)
+
+			in.Lifecycle.Append(fx.Hook{
+				OnStart: func(context.Context) error {
+					var ctx context.Context
+					ctx, cancel = context.WithCancel(context.Background())
+
+					// register for the signals before returning, so that none are missed
+					ch := sr.notify()
+					go func() {
+						defer close(done)
+						defer signal.Stop(ch)
+						sr.run(ctx, ch)
+					}()
+
+					return nil
+				},
+				OnStop: func(context.Context) error {
+					cancel()
+					<-done
+					return nil
+				},
+			})
+		},
+	)
+}
@@ -4,7 +4,11 @@
 package sallust
 
 import (
+	"os"
+	"path/filepath"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/fx"
@@ -18,16 +22,22 @@ type FxSuite struct {
 }
 
 func (suite *FxSuite) testWithLoggerDefault() {
-	var logger *zap.Logger
+	var (
+		logger *zap.Logger
+		lc     *LevelControl
+	)
+
 	app := fxtest.New(
 		suite.T(),
 		WithLogger(),
-		fx.Populate(&logger),
+		fx.Populate(&logger, &lc),
 	)
 
 	app.RequireStart()
 	app.RequireStop()
 	suite.Require().NotNil(logger)
+	suite.Require().NotNil(lc)
+	suite.Equal(zapcore.InfoLevel, lc.Level())
 	logger.Error("discarded")
 	suite.NoError(logger.Sync())
 }
@@ -125,6 +135,89 @@ func (suite *FxSuite) TestSyncOnShutdown() {
 	suite.True(logger.Core().(*syncCore).synced)
 }
 
+func (suite *FxSuite) TestSyncOnShutdownBuffering() {
+	var (
+		logger *zap.Logger
+		file   = filepath.Join(suite.T().TempDir(), "buffered.json")
+
+		app = fxtest.New(
+			suite.T(),
+			fx.Supply(
+				Config{
+					OutputPaths: []string{file},
+					Buffering: &BufferingConfig{
+						FlushInterval: time.Hour,
+					},
+				},
+			),
+			WithLogger(),
+			fx.Populate(&logger),
+			SyncOnShutdown(),
+		)
+	)
+
+	app.RequireStart()
+	suite.Require().NotNil(logger)
+	logger.Info("buffered")
+
+	contents, err := os.ReadFile(file)
+	suite.Require().NoError(err)
+	suite.NotContains(string(contents), "buffered")
+
+	app.RequireStop()
+	contents, err = os.ReadFile(file)
+	suite.Require().NoError(err)
+	suite.Contains(string(contents), "buffered")
+
+	// once buffering has stopped, entries are written directly
+	logger.Info("after shutdown")
+	contents, err = os.ReadFile(file)
+	suite.Require().NoError(err)
+	suite.Contains(string(contents), "after shutdown")
+}
+
+func (suite *FxSuite) TestRotateOnSignal() {
+	var (
+		logger *zap.Logger
+		dir    = suite.T().TempDir()
+		file   = filepath.Join(dir, "test.json")
+		moved  = filepath.Join(dir, "test.json.1")
+
+		app = fxtest.New(
+			suite.T(),
+			fx.Supply(
+				Config{
+					OutputPaths: []string{file},
+				},
+			),
+			WithLogger(),
+			fx.Populate(&logger),
+			RotateOnSignal(syscall.SIGHUP),
+		)
+	)
+
+	app.RequireStart()
+	defer app.RequireStop()
+
+	suite.Require().NotNil(logger)
+	logger.Info("before")
+	suite.Require().NoError(os.Rename(file, moved))
+
+	testRaise(suite.T(), syscall.SIGHUP)
+	suite.Eventually(
+		func() bool {
+			_, err := os.Stat(file)
+			return err == nil
+		},
+		time.Second,
+		10*time.Millisecond,
+	)
+
+	logger.Info("after")
+	suite.Contains(testReadFile(suite.T(), moved), "before")
+	suite.Contains(testReadFile(suite.T(), file), "after")
+}
+
 func TestFx(t *testing.T) {
 	suite.Run(t, new(FxSuite))
 }
@@ -0,0 +1,461 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// HTTPScheme is the URL scheme for posting batches of log entries to a collector over HTTP,
+	// e.g. http://collector:8080/logs?batchSize=500
+	HTTPScheme = "http"
+
+	// HTTPSScheme is the URL scheme for posting batches of log entries to a collector over HTTPS.
+	HTTPSScheme = "https"
+
+	// BatchSizeParameter is the HTTP URL parameter for the maximum number of entries in a batch.
+	BatchSizeParameter = "batchSize"
+
+	// FlushIntervalParameter is the HTTP URL parameter for the longest time an entry waits
+	// before its batch is posted.  The value is parsed with time.ParseDuration.
+	FlushIntervalParameter = "flushInterval"
+
+	// GzipParameter is the HTTP URL parameter that enables gzip compression of each batch.
+	GzipParameter = "gzip"
+
+	// HeaderParameter is the HTTP URL parameter for a request header, given as "Name: value".
+	// This parameter may be repeated.
+	HeaderParameter = "header"
+
+	// MaxRetriesParameter is the HTTP URL parameter for the number of times a failed batch is
+	// posted again.  Zero disables retries.
+	MaxRetriesParameter = "maxRetries"
+
+	// RetryBackoffParameter is the HTTP URL parameter for the delay before the first retry.  The
+	// delay doubles before each further retry.
+	RetryBackoffParameter = "retryBackoff"
+
+	// TimeoutParameter is the HTTP URL parameter for the time allowed for each request.
+	TimeoutParameter = "timeout"
+
+	// OnFullParameter is the HTTP URL parameter for what happens when too many batches are
+	// waiting to be posted:  either BufferDrop, which discards the oldest waiting batch, or
+	// BufferBlock, which makes writes wait until there is room.  The default is BufferDrop.
+	OnFullParameter = "onFull"
+
+	// DefaultBatchSize is the batch size used when an HTTP URL has no BatchSizeParameter.
+	DefaultBatchSize = 100
+
+	// DefaultMaxRetries is the number of retries used when an HTTP URL has no MaxRetriesParameter.
+	DefaultMaxRetries = 3
+
+	// DefaultRetryBackoff is the delay used when an HTTP URL has no RetryBackoffParameter.
+	DefaultRetryBackoff = 250 * time.Millisecond
+
+	// DefaultHTTPTimeout is the timeout used when an HTTP URL has no TimeoutParameter.
+	DefaultHTTPTimeout = 10 * time.Second
+
+	// DefaultBatchContentType is the Content-Type of posted batches, unless a HeaderParameter
+	// supplies a different one.
+	DefaultBatchContentType = "application/x-ndjson"
+
+	// maxPendingBatches is the number of batches that may wait to be posted before
+	// batches are dropped or writes block.
+	maxPendingBatches = 16
+)
+
+// httpBatchParameters are the query parameters consumed by the HTTP batch sink.  All
+// other query parameters are sent to the collector.
+var httpBatchParameters = []string{
+	BatchSizeParameter,
+	FlushIntervalParameter,
+	GzipParameter,
+	HeaderParameter,
+	MaxRetriesParameter,
+	OnFullParameter,
+	RetryBackoffParameter,
+	TimeoutParameter,
+}
+
+// errHTTPBatchClosed is returned for writes to a closed HTTPBatch sink.
+var errHTTPBatchClosed = errors.New("http batch sink is closed")
+
+func init() {
+	registerSink(HTTPScheme, NewHTTPBatchSink)
+	registerSink(HTTPSScheme, NewHTTPBatchSink)
+}
+
+// HTTPBatch is a zap.Sink that collects log entries into batches and posts each batch to
+// a collector endpoint.  A batch is posted once it holds the batch size number of entries,
+// or once the flush interval has elapsed since its first entry was written.  The request body
+// holds the entries exactly as encoded, one after another.
+//
+// Batches are posted in order by a background goroutine.  A batch that fails with a network
+// error, a 429 status, or a 5xx status is retried with exponential backoff.  If too many batches
+// are waiting to be posted, the oldest waiting batch is discarded, unless the sink was configured
+// to make writes block until there is room.
+//
+// Sync posts the current batch and then blocks until every batch written so far has been
+// acknowledged by the collector or has exhausted its retries.  Close does the same before
+// stopping the background goroutines.
+//
+// An HTTPBatch is safe for concurrent writes.  No additional synchronization is required.
+type HTTPBatch struct {
+	endpoint      string
+	client        *http.Client
+	header        http.Header
+	batchSize     int
+	flushInterval time.Duration
+	gzip          bool
+	maxRetries    int
+	retryBackoff  time.Duration
+	block         bool
+
+	lock    sync.Mutex
+	cond    *sync.Cond
+	batch   bytes.Buffer
+	count   int
+	pending [][]byte
+	sending bool
+	queued  uint64
+	posted  uint64
+	dropped uint64
+	err     error
+	closed  bool
+
+	done     chan struct{}
+	finished sync.WaitGroup
+}
+
+var _ zap.Sink = (*HTTPBatch)(nil)
+
+// newHTTPBatch parses an HTTP URL without starting any goroutines.
+func newHTTPBatch(u *url.URL) (*HTTPBatch, error) {
+	hb := &HTTPBatch{
+		header:        http.Header{"Content-Type": {DefaultBatchContentType}},
+		batchSize:     DefaultBatchSize,
+		flushInterval: DefaultFlushInterval,
+		maxRetries:    DefaultMaxRetries,
+		retryBackoff:  DefaultRetryBackoff,
+		done:          make(chan struct{}),
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != HTTPScheme && scheme != HTTPSScheme {
+		return nil, fmt.Errorf("Invalid http scheme [%s]", u.Scheme) // nolint:staticcheck
+	}
+
+	if len(u.Host) == 0 {
+		return nil, fmt.Errorf("Invalid http endpoint [%s]", u) // nolint:staticcheck
+	}
+
+	values, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	if v := values.Get(BatchSizeParameter); len(v) > 0 {
+		hb.batchSize, err = strconv.Atoi(v)
+		if err != nil || hb.batchSize < 1 {
+			return nil, fmt.Errorf("Invalid http batch size [%s]", v) // nolint:staticcheck
+		}
+	}
+
+	if v := values.Get(GzipParameter); len(v) > 0 {
+		hb.gzip, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if v := values.Get(MaxRetriesParameter); len(v) > 0 {
+		hb.maxRetries, err = strconv.Atoi(v)
+		if err != nil || hb.maxRetries < 0 {
+			return nil, fmt.Errorf("Invalid http max retries [%s]", v) // nolint:staticcheck
+		}
+	}
+
+	switch v := strings.ToLower(values.Get(OnFullParameter)); v {
+	case "", BufferDrop:
+	case BufferBlock:
+		hb.block = true
+	default:
+		return nil, fmt.Errorf("Invalid http onFull [%s]", v) // nolint:staticcheck
+	}
+
+	timeout := DefaultHTTPTimeout
+	for _, d := range []struct {
+		name  string
+		value *time.Duration
+	}{
+		{FlushIntervalParameter, &hb.flushInterval},
+		{RetryBackoffParameter, &hb.retryBackoff},
+		{TimeoutParameter, &timeout},
+	} {
+		if v := values.Get(d.name); len(v) > 0 {
+			*d.value, err = time.ParseDuration(v)
+			if err != nil || *d.value <= 0 {
+				return nil, fmt.Errorf("Invalid http %s [%s]", d.name, v) // nolint:staticcheck
+			}
+		}
+	}
+
+	for _, h := range values[HeaderParameter] {
+		name, value, ok := strings.Cut(h, ":")
+		name = strings.TrimSpace(name)
+		if !ok || len(name) == 0 {
+			return nil, fmt.Errorf("Invalid http header [%s]", h) // nolint:staticcheck
+		}
+
+		hb.header.Set(name, strings.TrimSpace(value))
+	}
+
+	for _, p := range httpBatchParameters {
+		values.Del(p)
+	}
+
+	endpoint := *u
+	endpoint.RawQuery = values.Encode()
+	hb.endpoint = endpoint.String()
+	hb.client = &http.Client{Timeout: timeout}
+	hb.cond = sync.NewCond(&hb.lock)
+	return hb, nil
+}
+
+// NewHTTPBatchSink creates a zap.Sink for an http or https URL.  The URL's query may contain
+// BatchSizeParameter, FlushIntervalParameter, GzipParameter, HeaderParameter, MaxRetriesParameter,
+// OnFullParameter, RetryBackoffParameter, and TimeoutParameter.  These parameters are removed from the URL, and
+// any others are sent to the collector.  This package registers this factory with zap.RegisterSink
+// for HTTPScheme and HTTPSScheme.
+func NewHTTPBatchSink(u *url.URL) (zap.Sink, error) {
+	hb, err := newHTTPBatch(u)
+	if err != nil {
+		return nil, err
+	}
+
+	hb.finished.Add(2)
+	go hb.send()
+	go hb.tick()
+	return hb, nil
+}
+
+// enqueue moves the current batch, if any, to the pending batches.  The lock must be held.
+func (hb *HTTPBatch) enqueue() {
+	if hb.count > 0 {
+		if !hb.block && len(hb.pending) >= maxPendingBatches {
+			hb.drop()
+		}
+
+		hb.pending = append(hb.pending, bytes.Clone(hb.batch.Bytes()))
+		hb.batch.Reset()
+		hb.count = 0
+		hb.queued++
+		hb.cond.Broadcast()
+	}
+}
+
+// drop discards the oldest pending batch that is not being posted.  A dropped batch counts
+// as posted, so that Sync does not wait for it.  The lock must be held.
+func (hb *HTTPBatch) drop() {
+	i := 0
+	if hb.sending {
+		i = 1
+	}
+
+	if i < len(hb.pending) {
+		hb.pending = append(hb.pending[:i:i], hb.pending[i+1:]...)
+		hb.posted++
+		hb.dropped++
+	}
+}
+
+// tick enqueues the current batch at each flush interval.
+func (hb *HTTPBatch) tick() {
+	defer hb.finished.Done()
+
+	ticker := time.NewTicker(hb.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-hb.done:
+			return
+
+		case <-ticker.C:
+			hb.lock.Lock()
+			hb.enqueue()
+			hb.lock.Unlock()
+		}
+	}
+}
+
+// send posts pending batches in order until this sink is closed.
+func (hb *HTTPBatch) send() {
+	defer hb.finished.Done()
+
+	hb.lock.Lock()
+	defer hb.lock.Unlock()
+
+	for {
+		for len(hb.pending) == 0 && !hb.closed {
+			hb.cond.Wait()
+		}
+
+		if len(hb.pending) == 0 {
+			return
+		}
+
+		batch := hb.pending[0]
+		hb.sending = true
+		hb.lock.Unlock()
+		err := hb.post(batch)
+		hb.lock.Lock()
+		hb.sending = false
+
+		hb.pending[0] = nil
+		hb.pending = hb.pending[1:]
+		hb.posted++
+		if err != nil {
+			hb.err = errors.Join(hb.err, err)
+		}
+
+		hb.cond.Broadcast()
+	}
+}
+
+// post sends one batch, retrying as necessary.
+func (hb *HTTPBatch) post(batch []byte) (err error) {
+	body := batch
+	if hb.gzip {
+		var b bytes.Buffer
+		gw := gzip.NewWriter(&b)
+		gw.Write(batch) // nolint:errcheck
+		gw.Close()
+		body = b.Bytes()
+	}
+
+	backoff := hb.retryBackoff
+	for attempt := 0; ; attempt++ {
+		var retry bool
+		retry, err = hb.attempt(body)
+		if err == nil || !retry || attempt >= hb.maxRetries {
+			return
+		}
+
+		select {
+		case <-hb.done:
+			return
+
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+// attempt makes one request for a batch, and reports whether a failure can be retried.
+func (hb *HTTPBatch) attempt(body []byte) (retry bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, hb.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header = hb.header.Clone()
+	if hb.gzip {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	resp, err := hb.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+
+	io.Copy(io.Discard, resp.Body) // nolint:errcheck
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		retry = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		err = fmt.Errorf("Collector [%s] responded with status [%d]", hb.endpoint, resp.StatusCode) // nolint:staticcheck
+	}
+
+	return
+}
+
+// Write adds p to the current batch, enqueueing the batch once it is full.
+func (hb *HTTPBatch) Write(p []byte) (int, error) {
+	hb.lock.Lock()
+	defer hb.lock.Unlock()
+
+	for hb.block && !hb.closed && len(hb.pending) >= maxPendingBatches {
+		hb.cond.Wait()
+	}
+
+	if hb.closed {
+		return 0, errHTTPBatchClosed
+	}
+
+	hb.batch.Write(p)
+	hb.count++
+	if hb.count >= hb.batchSize {
+		hb.enqueue()
+	}
+
+	return len(p), nil
+}
+
+// Dropped returns the number of batches discarded because too many batches were waiting
+// to be posted.
+func (hb *HTTPBatch) Dropped() uint64 {
+	hb.lock.Lock()
+	defer hb.lock.Unlock()
+	return hb.dropped
+}
+
+// Sync enqueues the current batch and waits until every batch has been posted.  The returned
+// error joins any failures since the last Sync.
+func (hb *HTTPBatch) Sync() error {
+	hb.lock.Lock()
+	defer hb.lock.Unlock()
+
+	hb.enqueue()
+	for target := hb.queued; hb.posted < target && !hb.closed; {
+		hb.cond.Wait()
+	}
+
+	err := hb.err
+	hb.err = nil
+	return err
+}
+
+// Close posts any remaining entries, as Sync does, and then stops the background goroutines.
+func (hb *HTTPBatch) Close() error {
+	err := hb.Sync()
+
+	hb.lock.Lock()
+	if hb.closed {
+		hb.lock.Unlock()
+		return nil
+	}
+
+	hb.closed = true
+	close(hb.done)
+	hb.cond.Broadcast()
+	hb.lock.Unlock()
+
+	hb.finished.Wait()
+	return err
+}
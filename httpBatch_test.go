@@ -0,0 +1,385 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCollector is an http.Handler that records the batches posted to it.  The
+// statuses, if any, are returned in order for the first requests.
+type testCollector struct {
+	lock     sync.Mutex
+	batches  []string
+	requests []*http.Request
+	statuses []int
+}
+
+func (tc *testCollector) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	var body io.Reader = request.Body
+	if request.Header.Get("Content-Encoding") == "gzip" {
+		gr, err := gzip.NewReader(body)
+		if err != nil {
+			response.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		body = gr
+	}
+
+	contents, err := io.ReadAll(body)
+	if err != nil {
+		response.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	tc.lock.Lock()
+	defer tc.lock.Unlock()
+	tc.requests = append(tc.requests, request)
+	if len(tc.statuses) > 0 {
+		status := tc.statuses[0]
+		tc.statuses = tc.statuses[1:]
+		if status != http.StatusOK {
+			response.WriteHeader(status)
+			return
+		}
+	}
+
+	tc.batches = append(tc.batches, string(contents))
+}
+
+func (tc *testCollector) Batches() []string {
+	tc.lock.Lock()
+	defer tc.lock.Unlock()
+	return append([]string{}, tc.batches...)
+}
+
+func (tc *testCollector) Requests() []*http.Request {
+	tc.lock.Lock()
+	defer tc.lock.Unlock()
+	return append([]*http.Request{}, tc.requests...)
+}
+
+// testHTTPBatchSink starts a collector and creates a sink that posts to it.
+func testHTTPBatchSink(t *testing.T, tc *testCollector, query string) *HTTPBatch {
+	server := httptest.NewServer(tc)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL + "/logs?" + query)
+	require.NoError(t, err)
+
+	sink, err := NewHTTPBatchSink(u)
+	require.NoError(t, err)
+	t.Cleanup(func() { sink.Close() })
+	return sink.(*HTTPBatch)
+}
+
+func testHTTPBatchInvalid(t *testing.T) {
+	for _, path := range []string{
+		"http:///logs",
+		"http://localhost/logs?batchSize=0",
+		"http://localhost/logs?flushInterval=soon",
+		"http://localhost/logs?gzip=maybe",
+		"https://localhost/logs?header=NoColon",
+		"https://localhost/logs?maxRetries=-1",
+		"https://localhost/logs?onFull=never",
+		"https://localhost/logs?retryBackoff=0s",
+		"https://localhost/logs?timeout=-1s",
+		"tcp://localhost:5170",
+	} {
+		t.Run(path, func(t *testing.T) {
+			u, err := url.Parse(path)
+			require.NoError(t, err)
+
+			s, err := NewHTTPBatchSink(u)
+			assert.Nil(t, s)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func testHTTPBatchSize(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		tc     = new(testCollector)
+		sink   = testHTTPBatchSink(t, tc, "batchSize=2&flushInterval=1h&tenant=a")
+	)
+
+	for _, entry := range []string{"one\n", "two\n", "three\n"} {
+		_, err := sink.Write([]byte(entry))
+		assert.NoError(err)
+	}
+
+	// a full batch is posted without a sync
+	assert.Eventually(
+		func() bool { return len(tc.Batches()) == 1 },
+		time.Second,
+		10*time.Millisecond,
+	)
+
+	assert.NoError(sink.Sync())
+	assert.Equal([]string{"one\ntwo\n", "three\n"}, tc.Batches())
+
+	for _, request := range tc.Requests() {
+		assert.Equal(http.MethodPost, request.Method)
+		assert.Equal("/logs", request.URL.Path)
+		assert.Equal("tenant=a", request.URL.RawQuery)
+		assert.Equal(DefaultBatchContentType, request.Header.Get("Content-Type"))
+	}
+}
+
+func testHTTPBatchFlushInterval(t *testing.T) {
+	var (
+		tc   = new(testCollector)
+		sink = testHTTPBatchSink(t, tc, "flushInterval=10ms")
+	)
+
+	sink.Write([]byte("flushed\n"))
+	assert.Eventually(
+		t,
+		func() bool {
+			batches := tc.Batches()
+			return len(batches) == 1 && batches[0] == "flushed\n"
+		},
+		time.Second,
+		10*time.Millisecond,
+	)
+}
+
+func testHTTPBatchGzipAndHeaders(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		tc     = new(testCollector)
+		sink   = testHTTPBatchSink(
+			t,
+			tc,
+			url.Values{
+				GzipParameter:   {"true"},
+				HeaderParameter: {"Authorization: Bearer token", "Content-Type: text/plain"},
+			}.Encode(),
+		)
+	)
+
+	sink.Write([]byte("compressed\n"))
+	assert.NoError(sink.Sync())
+	assert.Equal([]string{"compressed\n"}, tc.Batches())
+
+	requests := tc.Requests()
+	if assert.Len(requests, 1) {
+		assert.Equal("gzip", requests[0].Header.Get("Content-Encoding"))
+		assert.Equal("Bearer token", requests[0].Header.Get("Authorization"))
+		assert.Equal("text/plain", requests[0].Header.Get("Content-Type"))
+	}
+}
+
+func testHTTPBatchRetry(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		tc     = &testCollector{
+			statuses: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+		}
+
+		sink = testHTTPBatchSink(t, tc, "maxRetries=2&retryBackoff=1ms")
+	)
+
+	sink.Write([]byte("retried\n"))
+	assert.NoError(sink.Sync())
+	assert.Equal([]string{"retried\n"}, tc.Batches())
+	assert.Len(tc.Requests(), 3)
+
+	// client errors are not retried, and failures are reported by Sync
+	tc.lock.Lock()
+	tc.statuses = []int{http.StatusBadRequest}
+	tc.lock.Unlock()
+
+	sink.Write([]byte("rejected\n"))
+	assert.Error(sink.Sync())
+	assert.Len(tc.Requests(), 4)
+	assert.NoError(sink.Sync())
+}
+
+// testStalledCollector returns a handler that holds every request until release is
+// closed.  Each request is announced on the returned channel before it is held.
+func testStalledCollector(tc *testCollector, release <-chan struct{}) (http.Handler, <-chan struct{}) {
+	received := make(chan struct{}, 100)
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		received <- struct{}{}
+		<-release
+		tc.ServeHTTP(response, request)
+	}), received
+}
+
+// testStalledHTTPBatchSink creates a sink that posts each entry to a stalled collector,
+// and waits until the sink is posting its first entry.
+func testStalledHTTPBatchSink(t *testing.T, tc *testCollector, release <-chan struct{}, query string) *HTTPBatch {
+	handler, received := testStalledCollector(tc, release)
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL + "/logs?batchSize=1&flushInterval=1h&" + query)
+	require.NoError(t, err)
+
+	sink, err := NewHTTPBatchSink(u)
+	require.NoError(t, err)
+
+	_, err = sink.Write([]byte("0\n"))
+	require.NoError(t, err)
+
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no request")
+	}
+
+	return sink.(*HTTPBatch)
+}
+
+func testHTTPBatchDrop(t *testing.T) {
+	var (
+		assert   = assert.New(t)
+		require  = require.New(t)
+		tc       = new(testCollector)
+		release  = make(chan struct{})
+		sink     = testStalledHTTPBatchSink(t, tc, release, "")
+		expected = []string{"0\n"}
+	)
+
+	defer sink.Close()
+
+	// the batch being posted is never dropped, but the oldest waiting batches are
+	for i := 1; i < maxPendingBatches+4; i++ {
+		entry := strconv.Itoa(i) + "\n"
+		_, err := sink.Write([]byte(entry))
+		require.NoError(err)
+		if i > 4 {
+			expected = append(expected, entry)
+		}
+	}
+
+	assert.Equal(uint64(4), sink.Dropped())
+	close(release)
+	assert.NoError(sink.Sync())
+	assert.Equal(expected, tc.Batches())
+}
+
+func testHTTPBatchBlock(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		tc      = new(testCollector)
+		release = make(chan struct{})
+		sink    = testStalledHTTPBatchSink(t, tc, release, "onFull=block")
+		written = make(chan struct{})
+	)
+
+	defer sink.Close()
+	for i := 1; i < maxPendingBatches; i++ {
+		_, err := sink.Write([]byte(strconv.Itoa(i) + "\n"))
+		require.NoError(err)
+	}
+
+	go func() {
+		defer close(written)
+		sink.Write([]byte("blocked\n"))
+	}()
+
+	select {
+	case <-written:
+		assert.Fail("the write did not block")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case <-written:
+	case <-time.After(5 * time.Second):
+		require.FailNow("the write is still blocked")
+	}
+
+	assert.NoError(sink.Sync())
+	assert.Zero(sink.Dropped())
+	assert.Len(tc.Batches(), maxPendingBatches+1)
+}
+
+func testHTTPBatchConfig(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		tc      = new(testCollector)
+		server  = httptest.NewServer(tc)
+	)
+
+	defer server.Close()
+	l, err := Config{
+		OutputPaths: []string{server.URL + "/logs?flushInterval=1h"},
+		Permissions: "0600",
+	}.Build()
+
+	require.NoError(err)
+	l.Info("first")
+	l.Info("second")
+	assert.Empty(tc.Batches())
+
+	// syncing the logger waits for the collector
+	require.NoError(l.Sync())
+	batches := tc.Batches()
+	require.Len(batches, 1)
+	assert.Equal(2, strings.Count(batches[0], "\n"))
+	assert.Contains(batches[0], `"msg":"first"`)
+	assert.Contains(batches[0], `"msg":"second"`)
+}
+
+func testHTTPBatchClosed(t *testing.T) {
+	var (
+		tc   = new(testCollector)
+		sink = testHTTPBatchSink(t, tc, "")
+	)
+
+	sink.Write([]byte("before close\n"))
+	require.NoError(t, sink.Close())
+	require.NoError(t, sink.Close())
+	assert.Equal(t, []string{"before close\n"}, tc.Batches())
+
+	n, err := sink.Write([]byte("after close\n"))
+	assert.Zero(t, n)
+	assert.Error(t, err)
+}
+
+func testHTTPBatchValidate(t *testing.T) {
+	assert.Equal(
+		t,
+		[]string{"outputPaths[1]"},
+		validationPaths(t, Config{
+			OutputPaths: []string{
+				"https://collector.example.com/logs?batchSize=500",
+				"https://collector.example.com/logs?batchSize=none",
+			},
+		}.Validate()),
+	)
+}
+
+func TestHTTPBatch(t *testing.T) {
+	t.Run("Invalid", testHTTPBatchInvalid)
+	t.Run("Size", testHTTPBatchSize)
+	t.Run("FlushInterval", testHTTPBatchFlushInterval)
+	t.Run("GzipAndHeaders", testHTTPBatchGzipAndHeaders)
+	t.Run("Retry", testHTTPBatchRetry)
+	t.Run("Drop", testHTTPBatchDrop)
+	t.Run("Block", testHTTPBatchBlock)
+	t.Run("Config", testHTTPBatchConfig)
+	t.Run("Closed", testHTTPBatchClosed)
+	t.Run("Validate", testHTTPBatchValidate)
+}
@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// pendingRevert tracks a level change that will revert after a TTL.
+type pendingRevert struct {
+	gen   uint64
+	timer *time.Timer
+}
+
+func (pr pendingRevert) stop() {
+	if pr.timer != nil {
+		pr.timer.Stop()
+	}
+}
+
+// LevelControl is a runtime handle to the levels of a logger built from a Config.
+// It controls both the global level and the per-name levels described by Config.Levels.
+//
+// Each change may carry a TTL.  A change with a positive TTL reverts to the last
+// change made without a TTL, or to the configured value, once the TTL elapses.
+// A newer change to the same level always cancels any pending revert.
+//
+// A LevelControl is safe for concurrent use.
+type LevelControl struct {
+	level zap.AtomicLevel
+	names atomic.Pointer[nameLevels]
+
+	lock sync.Mutex
+	gen  uint64
+
+	baseLevel    zapcore.Level
+	levelPending pendingRevert
+
+	baseNames    map[string]zapcore.Level
+	currentNames map[string]zapcore.Level
+	namesPending map[string]pendingRevert
+}
+
+// NewLevelControl creates a LevelControl for the given global level and initial
+// per-name levels.  The level is shared, so changes through the returned
+// LevelControl are visible to anything else that uses it.
+func NewLevelControl(level zap.AtomicLevel, names map[string]zapcore.Level) *LevelControl {
+	lc := &LevelControl{
+		level:        level,
+		baseLevel:    level.Level(),
+		baseNames:    make(map[string]zapcore.Level, len(names)),
+		currentNames: make(map[string]zapcore.Level, len(names)),
+		namesPending: make(map[string]pendingRevert),
+	}
+
+	for name, l := range names {
+		lc.baseNames[name] = l
+		lc.currentNames[name] = l
+	}
+
+	lc.names.Store(newNameLevels(lc.currentNames))
+	return lc
+}
+
+// Level returns the current global level.
+func (lc *LevelControl) Level() zapcore.Level {
+	return lc.level.Level()
+}
+
+// AtomicLevel returns the global level.  Changes made directly to the returned
+// level are not subject to any TTL.
+func (lc *LevelControl) AtomicLevel() zap.AtomicLevel {
+	return lc.level
+}
+
+// SetLevel changes the global level.  If ttl is positive, the global level
+// reverts once ttl has elapsed.
+func (lc *LevelControl) SetLevel(l zapcore.Level, ttl time.Duration) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	lc.gen++
+	lc.levelPending.stop()
+	lc.levelPending = pendingRevert{}
+	lc.level.SetLevel(l)
+
+	if ttl > 0 {
+		gen := lc.gen
+		lc.levelPending = pendingRevert{
+			gen: gen,
+			timer: time.AfterFunc(ttl, func() {
+				lc.lock.Lock()
+				defer lc.lock.Unlock()
+				if lc.levelPending.gen == gen {
+					lc.level.SetLevel(lc.baseLevel)
+					lc.levelPending = pendingRevert{}
+				}
+			}),
+		}
+	} else {
+		lc.baseLevel = l
+	}
+}
+
+// NameLevels returns a copy of the current per-name levels.
+func (lc *LevelControl) NameLevels() map[string]zapcore.Level {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	copied := make(map[string]zapcore.Level, len(lc.currentNames))
+	for name, l := range lc.currentNames {
+		copied[name] = l
+	}
+
+	return copied
+}
+
+// SetNameLevel sets the minimum level for loggers with the given name prefix.
+// If ttl is positive, the level for that prefix reverts once ttl has elapsed.
+func (lc *LevelControl) SetNameLevel(name string, l zapcore.Level, ttl time.Duration) {
+	lc.setName(name, &l, ttl)
+}
+
+// ClearNameLevel removes the level for the given name prefix, so that matching
+// loggers fall back to any less specific prefix or to the global level.  If ttl is
+// positive, the level for that prefix is restored once ttl has elapsed.
+func (lc *LevelControl) ClearNameLevel(name string, ttl time.Duration) {
+	lc.setName(name, nil, ttl)
+}
+
+// updateName sets or removes a current per-name level.  The caller must hold the lock.
+func (lc *LevelControl) updateName(name string, l *zapcore.Level) {
+	if l != nil {
+		lc.currentNames[name] = *l
+	} else {
+		delete(lc.currentNames, name)
+	}
+
+	lc.names.Store(newNameLevels(lc.currentNames))
+}
+
+func (lc *LevelControl) setName(name string, l *zapcore.Level, ttl time.Duration) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	lc.gen++
+	lc.namesPending[name].stop()
+	delete(lc.namesPending, name)
+	lc.updateName(name, l)
+
+	switch {
+	case ttl > 0:
+		gen := lc.gen
+		lc.namesPending[name] = pendingRevert{
+			gen: gen,
+			timer: time.AfterFunc(ttl, func() {
+				lc.lock.Lock()
+				defer lc.lock.Unlock()
+				if lc.namesPending[name].gen == gen {
+					delete(lc.namesPending, name)
+					if base, ok := lc.baseNames[name]; ok {
+						lc.updateName(name, &base)
+					} else {
+						lc.updateName(name, nil)
+					}
+				}
+			}),
+		}
+
+	case l != nil:
+		lc.baseNames[name] = *l
+
+	default:
+		delete(lc.baseNames, name)
+	}
+}
+
+// reset replaces both the global and per-name levels, discarding any
+// pending reverts.  This is used when a logger is reloaded.
+func (lc *LevelControl) reset(level zapcore.Level, names map[string]zapcore.Level) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	lc.gen++
+	lc.levelPending.stop()
+	lc.levelPending = pendingRevert{}
+	for _, pr := range lc.namesPending {
+		pr.stop()
+	}
+
+	lc.namesPending = make(map[string]pendingRevert)
+	lc.baseLevel = level
+	lc.level.SetLevel(level)
+
+	lc.baseNames = make(map[string]zapcore.Level, len(names))
+	lc.currentNames = make(map[string]zapcore.Level, len(names))
+	for name, l := range names {
+		lc.baseNames[name] = l
+		lc.currentNames[name] = l
+	}
+
+	lc.names.Store(newNameLevels(lc.currentNames))
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type LevelControlSuite struct {
+	suite.Suite
+}
+
+func (suite *LevelControlSuite) newLevelControl() *LevelControl {
+	return NewLevelControl(
+		zap.NewAtomicLevelAt(zapcore.InfoLevel),
+		map[string]zapcore.Level{
+			"fx": zapcore.WarnLevel,
+		},
+	)
+}
+
+func (suite *LevelControlSuite) TestSetLevel() {
+	lc := suite.newLevelControl()
+	suite.Equal(zapcore.InfoLevel, lc.Level())
+
+	lc.SetLevel(zapcore.ErrorLevel, 0)
+	suite.Equal(zapcore.ErrorLevel, lc.Level())
+	suite.Equal(zapcore.ErrorLevel, lc.AtomicLevel().Level())
+
+	lc.SetLevel(zapcore.DebugLevel, 10*time.Millisecond)
+	suite.Equal(zapcore.DebugLevel, lc.Level())
+	suite.Eventually(
+		func() bool { return lc.Level() == zapcore.ErrorLevel },
+		time.Second,
+		5*time.Millisecond,
+	)
+}
+
+func (suite *LevelControlSuite) TestSetLevelCancelsRevert() {
+	lc := suite.newLevelControl()
+
+	lc.SetLevel(zapcore.DebugLevel, 10*time.Millisecond)
+	lc.SetLevel(zapcore.WarnLevel, 0)
+	time.Sleep(50 * time.Millisecond)
+	suite.Equal(zapcore.WarnLevel, lc.Level())
+}
+
+func (suite *LevelControlSuite) TestSetNameLevel() {
+	lc := suite.newLevelControl()
+	suite.Equal(map[string]zapcore.Level{"fx": zapcore.WarnLevel}, lc.NameLevels())
+
+	lc.SetNameLevel("http", zapcore.DebugLevel, 0)
+	suite.Equal(
+		map[string]zapcore.Level{"fx": zapcore.WarnLevel, "http": zapcore.DebugLevel},
+		lc.NameLevels(),
+	)
+
+	lc.SetNameLevel("fx", zapcore.DebugLevel, 10*time.Millisecond)
+	suite.Equal(zapcore.DebugLevel, lc.NameLevels()["fx"])
+	suite.Eventually(
+		func() bool { return lc.NameLevels()["fx"] == zapcore.WarnLevel },
+		time.Second,
+		5*time.Millisecond,
+	)
+
+	lc.SetNameLevel("temporary", zapcore.DebugLevel, 10*time.Millisecond)
+	suite.Contains(lc.NameLevels(), "temporary")
+	suite.Eventually(
+		func() bool {
+			_, ok := lc.NameLevels()["temporary"]
+			return !ok
+		},
+		time.Second,
+		5*time.Millisecond,
+	)
+}
+
+func (suite *LevelControlSuite) TestClearNameLevel() {
+	lc := suite.newLevelControl()
+
+	lc.ClearNameLevel("fx", 10*time.Millisecond)
+	suite.Empty(lc.NameLevels())
+	suite.Eventually(
+		func() bool { return lc.NameLevels()["fx"] == zapcore.WarnLevel },
+		time.Second,
+		5*time.Millisecond,
+	)
+
+	lc.ClearNameLevel("fx", 0)
+	suite.Empty(lc.NameLevels())
+
+	_, ok := lc.names.Load().find("fx")
+	suite.False(ok)
+}
+
+func TestLevelControl(t *testing.T) {
+	suite.Run(t, new(LevelControlSuite))
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// nameLevel is a minimum log level for loggers with a given name prefix.
+type nameLevel struct {
+	prefix string
+	level  zapcore.Level
+}
+
+// nameLevels is an immutable set of minimum levels keyed by logger name prefix.
+// A nil *nameLevels has no overrides.
+type nameLevels struct {
+	// entries is sorted so that longer prefixes come first, which means
+	// the first match is always the most specific one.
+	entries []nameLevel
+
+	// min is the lowest level of all the entries
+	min zapcore.Level
+}
+
+// parseNameLevels parses the Config.Levels map.
+func parseNameLevels(m map[string]string) (map[string]zapcore.Level, error) {
+	parsed := make(map[string]zapcore.Level, len(m))
+	for prefix, text := range m {
+		var l zapcore.Level
+		if err := l.UnmarshalText([]byte(text)); err != nil {
+			return nil, fmt.Errorf("levels[%s]: %w", prefix, err)
+		}
+
+		parsed[prefix] = l
+	}
+
+	return parsed, nil
+}
+
+// newNameLevels creates the lookup structure for a set of per-name levels.
+// If the map is empty, this function returns a nil *nameLevels.
+func newNameLevels(m map[string]zapcore.Level) *nameLevels {
+	if len(m) == 0 {
+		return nil
+	}
+
+	nl := &nameLevels{
+		entries: make([]nameLevel, 0, len(m)),
+		min:     zapcore.InvalidLevel,
+	}
+
+	for prefix, l := range m {
+		nl.entries = append(nl.entries, nameLevel{prefix: prefix, level: l})
+		if l < nl.min {
+			nl.min = l
+		}
+	}
+
+	sort.Slice(nl.entries, func(i, j int) bool {
+		if len(nl.entries[i].prefix) != len(nl.entries[j].prefix) {
+			return len(nl.entries[i].prefix) > len(nl.entries[j].prefix)
+		}
+
+		return nl.entries[i].prefix < nl.entries[j].prefix
+	})
+
+	return nl
+}
+
+// matchesName tests if a logger name is the given prefix or a descendant of it.
+// zap.Logger.Named separates name segments with a period.
+func matchesName(prefix, name string) bool {
+	return strings.HasPrefix(name, prefix) &&
+		(len(name) == len(prefix) || name[len(prefix)] == '.')
+}
+
+// find returns the minimum level for the most specific prefix that matches
+// the given logger name.
+func (nl *nameLevels) find(name string) (zapcore.Level, bool) {
+	if nl == nil {
+		return zapcore.InvalidLevel, false
+	}
+
+	for _, e := range nl.entries {
+		if matchesName(e.prefix, name) {
+			return e.level, true
+		}
+	}
+
+	return zapcore.InvalidLevel, false
+}
+
+// enabled tests if any override could enable the given level.
+func (nl *nameLevels) enabled(l zapcore.Level) bool {
+	return nl != nil && l >= nl.min
+}
+
+// levelCore decorates a zapcore.Core with level checking that honors the
+// per-name levels of a LevelControl.  The decorated core must itself be
+// enabled for every level, since this type performs all level checks.  The
+// decorated core is still consulted, so that it can decline entries for other
+// reasons, such as a tail with no subscribers.
+type levelCore struct {
+	zapcore.Core
+
+	level   zapcore.LevelEnabler
+	control *LevelControl
+}
+
+var _ zapcore.Core = levelCore{}
+
+// Enabled returns true if either this core's level or any per-name
+// level enables the given level, and the decorated core is enabled.
+func (lc levelCore) Enabled(l zapcore.Level) bool {
+	return (lc.level.Enabled(l) || lc.control.names.Load().enabled(l)) && lc.Core.Enabled(l)
+}
+
+// With returns a levelCore with the same level configuration that
+// decorates the child core.
+func (lc levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return levelCore{
+		Core:    lc.Core.With(fields),
+		level:   lc.level,
+		control: lc.control,
+	}
+}
+
+// Check uses the entry's logger name to determine the effective level, and then
+// lets the decorated core check the entry.
+func (lc levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if l, ok := lc.control.names.Load().find(ent.LoggerName); ok {
+		if ent.Level >= l {
+			return lc.Core.Check(ent, ce)
+		}
+	} else if lc.level.Enabled(ent.Level) {
+		return lc.Core.Check(ent, ce)
+	}
+
+	return ce
+}
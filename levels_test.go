@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func testMatchesName(t *testing.T) {
+	testData := []struct {
+		prefix   string
+		name     string
+		expected bool
+	}{
+		{prefix: "http", name: "http", expected: true},
+		{prefix: "http", name: "http.access", expected: true},
+		{prefix: "http.access", name: "http.access.detail", expected: true},
+		{prefix: "http", name: "httpclient", expected: false},
+		{prefix: "http.access", name: "http", expected: false},
+		{prefix: "fx", name: "", expected: false},
+	}
+
+	for i, record := range testData {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			assert.Equal(t, record.expected, matchesName(record.prefix, record.name))
+		})
+	}
+}
+
+func testParseNameLevels(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		parsed, err := parseNameLevels(map[string]string{
+			"fx":   "warn",
+			"http": "debug",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(
+			t,
+			map[string]zapcore.Level{
+				"fx":   zapcore.WarnLevel,
+				"http": zapcore.DebugLevel,
+			},
+			parsed,
+		)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		parsed, err := parseNameLevels(map[string]string{
+			"fx": "this is not a valid level",
+		})
+
+		assert.ErrorContains(t, err, "levels[fx]")
+		assert.Nil(t, parsed)
+	})
+}
+
+func testNewNameLevels(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		nl := newNameLevels(nil)
+		assert.Nil(t, nl)
+		assert.False(t, nl.enabled(zapcore.FatalLevel))
+
+		_, ok := nl.find("anything")
+		assert.False(t, ok)
+	})
+
+	t.Run("MostSpecific", func(t *testing.T) {
+		require := require.New(t)
+		assert := assert.New(t)
+
+		nl := newNameLevels(map[string]zapcore.Level{
+			"http":        zapcore.WarnLevel,
+			"http.access": zapcore.DebugLevel,
+			"fx":          zapcore.ErrorLevel,
+		})
+
+		require.NotNil(nl)
+
+		l, ok := nl.find("http.access.detail")
+		assert.True(ok)
+		assert.Equal(zapcore.DebugLevel, l)
+
+		l, ok = nl.find("http.server")
+		assert.True(ok)
+		assert.Equal(zapcore.WarnLevel, l)
+
+		_, ok = nl.find("other")
+		assert.False(ok)
+
+		assert.True(nl.enabled(zapcore.DebugLevel))
+	})
+}
+
+func testLevelCore(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		buffer bytes.Buffer
+
+		control = NewLevelControl(
+			zap.NewAtomicLevelAt(zapcore.InfoLevel),
+			map[string]zapcore.Level{
+				"fx":          zapcore.WarnLevel,
+				"http.access": zapcore.DebugLevel,
+			},
+		)
+	)
+
+	l := zap.New(levelCore{
+		Core: zapcore.NewCore(
+			zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+			zapcore.AddSync(&buffer),
+			zapcore.DebugLevel,
+		),
+		level:   control.AtomicLevel(),
+		control: control,
+	})
+
+	assert.True(l.Core().Enabled(zapcore.DebugLevel))
+
+	l.Debug("root debug")
+	l.Info("root info")
+	l.Named("fx").Info("fx info")
+	l.Named("fx").Warn("fx warn")
+	l.Named("http").Named("access").With(zap.String("foo", "bar")).Debug("access debug")
+
+	output := buffer.String()
+	assert.NotContains(output, "root debug")
+	assert.Contains(output, "root info")
+	assert.NotContains(output, "fx info")
+	assert.Contains(output, "fx warn")
+	assert.Contains(output, "access debug")
+	assert.Contains(output, `"foo":"bar"`)
+
+	buffer.Reset()
+	control.ClearNameLevel("http.access", 0)
+	assert.False(l.Core().Enabled(zapcore.DebugLevel))
+	l.Named("http").Named("access").Debug("access debug")
+	assert.Empty(buffer.String())
+}
+
+func TestLevels(t *testing.T) {
+	t.Run("MatchesName", testMatchesName)
+	t.Run("ParseNameLevels", testParseNameLevels)
+	t.Run("NewNameLevels", testNewNameLevels)
+	t.Run("LevelCore", testLevelCore)
+}
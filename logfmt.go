@@ -0,0 +1,508 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// LogfmtEncoding is the zap encoding name of the logfmt encoder.  This encoding is registered
+// with zap.RegisterEncoder when this package is imported, so it can be used for Config.Encoding
+// and CoreConfig.Encoding.
+const LogfmtEncoding = "logfmt"
+
+var logfmtPool = buffer.NewPool()
+
+func init() {
+	zap.RegisterEncoder(LogfmtEncoding, func(cfg zapcore.EncoderConfig) (zapcore.Encoder, error) {
+		return NewLogfmtEncoder(cfg), nil
+	})
+}
+
+// NewLogfmtEncoder creates a zapcore.Encoder that writes each log entry as a single line of
+// space-separated key=value pairs.  Every key and encoder function of the EncoderConfig is
+// honored in the same way as zapcore.NewJSONEncoder, and a key that is empty omits that part
+// of the entry.
+//
+// Values are quoted only when necessary, i.e. when they are empty or contain spaces, equals
+// signs, quotes, or unprintable characters.  Object fields and namespaces are flattened into
+// dotted keys, such as "request.method=GET".  Arrays are written as comma-separated elements
+// in square brackets, such as "ids=[1,2,3]", with objects inside arrays written in curly braces.
+// Reflected values are written as JSON, or with the EncoderConfig's NewReflectedEncoder.
+func NewLogfmtEncoder(cfg zapcore.EncoderConfig) zapcore.Encoder {
+	return &logfmtEncoder{
+		cfg: &cfg,
+		buf: logfmtPool.Get(),
+	}
+}
+
+// logfmtEncoder is the zapcore.Encoder for LogfmtEncoding.
+type logfmtEncoder struct {
+	cfg *zapcore.EncoderConfig
+	buf *buffer.Buffer
+
+	// namespaces are prefixed to each key, separated by dots
+	namespaces []string
+}
+
+var _ zapcore.Encoder = (*logfmtEncoder)(nil)
+
+// clone creates an empty encoder with the same configuration and namespaces.
+func (enc *logfmtEncoder) clone() *logfmtEncoder {
+	return &logfmtEncoder{
+		cfg:        enc.cfg,
+		buf:        logfmtPool.Get(),
+		namespaces: enc.namespaces[:len(enc.namespaces):len(enc.namespaces)],
+	}
+}
+
+func (enc *logfmtEncoder) Clone() zapcore.Encoder {
+	clone := enc.clone()
+	clone.buf.Write(enc.buf.Bytes())
+	return clone
+}
+
+// addKey writes the separator, if needed, along with the full key for a value.
+func (enc *logfmtEncoder) addKey(key string) {
+	if enc.buf.Len() > 0 {
+		enc.buf.AppendByte(' ')
+	}
+
+	for _, ns := range enc.namespaces {
+		appendLogfmtKey(enc.buf, ns)
+		enc.buf.AppendByte('.')
+	}
+
+	appendLogfmtKey(enc.buf, key)
+	enc.buf.AppendByte('=')
+}
+
+// addValue writes a value produced by appending to a logfmtArrayEncoder.  This is how the
+// encoder functions from the EncoderConfig, which may append several elements, are applied.
+func (enc *logfmtEncoder) addValue(key string, encode func(*logfmtArrayEncoder)) {
+	arr := &logfmtArrayEncoder{
+		cfg: enc.cfg,
+		buf: logfmtPool.Get(),
+	}
+
+	defer arr.buf.Free()
+	encode(arr)
+	enc.addKey(key)
+	appendLogfmtString(enc.buf, arr.buf.String())
+}
+
+func (enc *logfmtEncoder) AddArray(key string, v zapcore.ArrayMarshaler) (err error) {
+	enc.addValue(key, func(arr *logfmtArrayEncoder) {
+		err = arr.AppendArray(v)
+	})
+
+	return
+}
+
+// AddObject flattens the object's fields into this encoder, prefixing their keys with
+// the given key.  Any namespaces opened by the object are closed afterward.
+func (enc *logfmtEncoder) AddObject(key string, v zapcore.ObjectMarshaler) error {
+	n := len(enc.namespaces)
+	enc.namespaces = append(enc.namespaces, key)
+	err := v.MarshalLogObject(enc)
+	enc.namespaces = enc.namespaces[:n]
+	return err
+}
+
+func (enc *logfmtEncoder) AddBinary(key string, v []byte) {
+	enc.AddString(key, base64.StdEncoding.EncodeToString(v))
+}
+
+func (enc *logfmtEncoder) AddByteString(key string, v []byte) {
+	enc.AddString(key, string(v))
+}
+
+func (enc *logfmtEncoder) AddBool(key string, v bool) {
+	enc.addKey(key)
+	enc.buf.AppendBool(v)
+}
+
+func (enc *logfmtEncoder) AddComplex128(key string, v complex128) {
+	enc.addKey(key)
+	appendLogfmtComplex(enc.buf, v, 64)
+}
+
+func (enc *logfmtEncoder) AddComplex64(key string, v complex64) {
+	enc.addKey(key)
+	appendLogfmtComplex(enc.buf, complex128(v), 32)
+}
+
+func (enc *logfmtEncoder) AddDuration(key string, v time.Duration) {
+	enc.addValue(key, func(arr *logfmtArrayEncoder) {
+		arr.AppendDuration(v)
+	})
+}
+
+func (enc *logfmtEncoder) AddFloat64(key string, v float64) {
+	enc.addKey(key)
+	appendLogfmtFloat(enc.buf, v, 64)
+}
+
+func (enc *logfmtEncoder) AddFloat32(key string, v float32) {
+	enc.addKey(key)
+	appendLogfmtFloat(enc.buf, float64(v), 32)
+}
+
+func (enc *logfmtEncoder) AddInt(key string, v int)     { enc.AddInt64(key, int64(v)) }
+func (enc *logfmtEncoder) AddInt32(key string, v int32) { enc.AddInt64(key, int64(v)) }
+func (enc *logfmtEncoder) AddInt16(key string, v int16) { enc.AddInt64(key, int64(v)) }
+func (enc *logfmtEncoder) AddInt8(key string, v int8)   { enc.AddInt64(key, int64(v)) }
+
+func (enc *logfmtEncoder) AddInt64(key string, v int64) {
+	enc.addKey(key)
+	enc.buf.AppendInt(v)
+}
+
+func (enc *logfmtEncoder) AddString(key, v string) {
+	enc.addKey(key)
+	appendLogfmtString(enc.buf, v)
+}
+
+func (enc *logfmtEncoder) AddTime(key string, v time.Time) {
+	enc.addValue(key, func(arr *logfmtArrayEncoder) {
+		arr.AppendTime(v)
+	})
+}
+
+func (enc *logfmtEncoder) AddUint(key string, v uint)       { enc.AddUint64(key, uint64(v)) }
+func (enc *logfmtEncoder) AddUint32(key string, v uint32)   { enc.AddUint64(key, uint64(v)) }
+func (enc *logfmtEncoder) AddUint16(key string, v uint16)   { enc.AddUint64(key, uint64(v)) }
+func (enc *logfmtEncoder) AddUint8(key string, v uint8)     { enc.AddUint64(key, uint64(v)) }
+func (enc *logfmtEncoder) AddUintptr(key string, v uintptr) { enc.AddUint64(key, uint64(v)) }
+
+func (enc *logfmtEncoder) AddUint64(key string, v uint64) {
+	enc.addKey(key)
+	enc.buf.AppendUint(v)
+}
+
+func (enc *logfmtEncoder) AddReflected(key string, v interface{}) (err error) {
+	enc.addValue(key, func(arr *logfmtArrayEncoder) {
+		err = arr.AppendReflected(v)
+	})
+
+	return
+}
+
+func (enc *logfmtEncoder) OpenNamespace(key string) {
+	enc.namespaces = append(enc.namespaces, key)
+}
+
+// EncodeEntry writes the entry's metadata first, then the accumulated context, then
+// the fields, and finally any stacktrace.  This is the same order used by zapcore's
+// JSON encoder.
+func (enc *logfmtEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	var (
+		cfg   = enc.cfg
+		final = &logfmtEncoder{
+			cfg: cfg,
+			buf: logfmtPool.Get(),
+		}
+	)
+
+	if len(cfg.TimeKey) > 0 && !ent.Time.IsZero() {
+		final.AddTime(cfg.TimeKey, ent.Time)
+	}
+
+	if len(cfg.LevelKey) > 0 && cfg.EncodeLevel != nil {
+		final.addValue(cfg.LevelKey, func(arr *logfmtArrayEncoder) {
+			cfg.EncodeLevel(ent.Level, arr)
+		})
+	}
+
+	if len(cfg.NameKey) > 0 && len(ent.LoggerName) > 0 {
+		encodeName := cfg.EncodeName
+		if encodeName == nil {
+			encodeName = zapcore.FullNameEncoder
+		}
+
+		final.addValue(cfg.NameKey, func(arr *logfmtArrayEncoder) {
+			encodeName(ent.LoggerName, arr)
+		})
+	}
+
+	if ent.Caller.Defined {
+		if len(cfg.CallerKey) > 0 && cfg.EncodeCaller != nil {
+			final.addValue(cfg.CallerKey, func(arr *logfmtArrayEncoder) {
+				cfg.EncodeCaller(ent.Caller, arr)
+			})
+		}
+
+		if len(cfg.FunctionKey) > 0 {
+			final.AddString(cfg.FunctionKey, ent.Caller.Function)
+		}
+	}
+
+	if len(cfg.MessageKey) > 0 {
+		final.AddString(cfg.MessageKey, ent.Message)
+	}
+
+	if enc.buf.Len() > 0 {
+		if final.buf.Len() > 0 {
+			final.buf.AppendByte(' ')
+		}
+
+		final.buf.Write(enc.buf.Bytes())
+	}
+
+	// fields go into any namespaces opened by the context, but the stacktrace does not
+	final.namespaces = enc.namespaces[:len(enc.namespaces):len(enc.namespaces)]
+	for _, f := range fields {
+		f.AddTo(final)
+	}
+
+	final.namespaces = nil
+	if len(cfg.StacktraceKey) > 0 && len(ent.Stack) > 0 {
+		final.AddString(cfg.StacktraceKey, ent.Stack)
+	}
+
+	if !cfg.SkipLineEnding {
+		if len(cfg.LineEnding) > 0 {
+			final.buf.AppendString(cfg.LineEnding)
+		} else {
+			final.buf.AppendString(zapcore.DefaultLineEnding)
+		}
+	}
+
+	return final.buf, nil
+}
+
+// logfmtArrayEncoder writes comma-separated values.  As an array, its strings are quoted
+// when needed to keep the elements apart.  As the output of an encoder function, such as
+// a zapcore.TimeEncoder, its strings are written as is.
+type logfmtArrayEncoder struct {
+	cfg   *zapcore.EncoderConfig
+	buf   *buffer.Buffer
+	list  bool
+	count int
+}
+
+var _ zapcore.ArrayEncoder = (*logfmtArrayEncoder)(nil)
+
+// separate writes a comma before every element but the first.
+func (arr *logfmtArrayEncoder) separate() {
+	if arr.count > 0 {
+		arr.buf.AppendByte(',')
+	}
+
+	arr.count++
+}
+
+func (arr *logfmtArrayEncoder) AppendArray(v zapcore.ArrayMarshaler) error {
+	arr.separate()
+	nested := &logfmtArrayEncoder{
+		cfg:  arr.cfg,
+		buf:  arr.buf,
+		list: true,
+	}
+
+	arr.buf.AppendByte('[')
+	err := v.MarshalLogArray(nested)
+	arr.buf.AppendByte(']')
+	return err
+}
+
+func (arr *logfmtArrayEncoder) AppendObject(v zapcore.ObjectMarshaler) error {
+	arr.separate()
+	obj := &logfmtEncoder{
+		cfg: arr.cfg,
+		buf: logfmtPool.Get(),
+	}
+
+	defer obj.buf.Free()
+	err := v.MarshalLogObject(obj)
+	arr.buf.AppendByte('{')
+	arr.buf.Write(obj.buf.Bytes())
+	arr.buf.AppendByte('}')
+	return err
+}
+
+func (arr *logfmtArrayEncoder) AppendReflected(v interface{}) error {
+	b := logfmtPool.Get()
+	defer b.Free()
+
+	var re zapcore.ReflectedEncoder
+	if arr.cfg.NewReflectedEncoder != nil {
+		re = arr.cfg.NewReflectedEncoder(b)
+	} else {
+		je := json.NewEncoder(b)
+		je.SetEscapeHTML(false)
+		re = je
+	}
+
+	err := re.Encode(v)
+	if err == nil {
+		arr.AppendString(strings.TrimSuffix(b.String(), "\n"))
+	}
+
+	return err
+}
+
+func (arr *logfmtArrayEncoder) AppendBool(v bool) {
+	arr.separate()
+	arr.buf.AppendBool(v)
+}
+
+func (arr *logfmtArrayEncoder) AppendByteString(v []byte) {
+	arr.AppendString(string(v))
+}
+
+func (arr *logfmtArrayEncoder) AppendComplex128(v complex128) {
+	arr.separate()
+	appendLogfmtComplex(arr.buf, v, 64)
+}
+
+func (arr *logfmtArrayEncoder) AppendComplex64(v complex64) {
+	arr.separate()
+	appendLogfmtComplex(arr.buf, complex128(v), 32)
+}
+
+// AppendDuration uses the EncodeDuration function, falling back to nanoseconds
+// if that function is unset or appends nothing.
+func (arr *logfmtArrayEncoder) AppendDuration(v time.Duration) {
+	count := arr.count
+	if arr.cfg.EncodeDuration != nil {
+		arr.cfg.EncodeDuration(v, arr)
+	}
+
+	if arr.count == count {
+		arr.AppendInt64(int64(v))
+	}
+}
+
+func (arr *logfmtArrayEncoder) AppendFloat64(v float64) {
+	arr.separate()
+	appendLogfmtFloat(arr.buf, v, 64)
+}
+
+func (arr *logfmtArrayEncoder) AppendFloat32(v float32) {
+	arr.separate()
+	appendLogfmtFloat(arr.buf, float64(v), 32)
+}
+
+func (arr *logfmtArrayEncoder) AppendInt(v int)     { arr.AppendInt64(int64(v)) }
+func (arr *logfmtArrayEncoder) AppendInt32(v int32) { arr.AppendInt64(int64(v)) }
+func (arr *logfmtArrayEncoder) AppendInt16(v int16) { arr.AppendInt64(int64(v)) }
+func (arr *logfmtArrayEncoder) AppendInt8(v int8)   { arr.AppendInt64(int64(v)) }
+
+func (arr *logfmtArrayEncoder) AppendInt64(v int64) {
+	arr.separate()
+	arr.buf.AppendInt(v)
+}
+
+func (arr *logfmtArrayEncoder) AppendString(v string) {
+	arr.separate()
+	if arr.list && (logfmtNeedsQuotes(v) || strings.ContainsAny(v, ",[]{}")) {
+		arr.buf.AppendString(strconv.Quote(v))
+	} else {
+		arr.buf.AppendString(v)
+	}
+}
+
+// AppendTime uses the EncodeTime function, falling back to nanoseconds since the
+// epoch if that function is unset or appends nothing.
+func (arr *logfmtArrayEncoder) AppendTime(v time.Time) {
+	count := arr.count
+	if arr.cfg.EncodeTime != nil {
+		arr.cfg.EncodeTime(v, arr)
+	}
+
+	if arr.count == count {
+		arr.AppendInt64(v.UnixNano())
+	}
+}
+
+func (arr *logfmtArrayEncoder) AppendUint(v uint)       { arr.AppendUint64(uint64(v)) }
+func (arr *logfmtArrayEncoder) AppendUint32(v uint32)   { arr.AppendUint64(uint64(v)) }
+func (arr *logfmtArrayEncoder) AppendUint16(v uint16)   { arr.AppendUint64(uint64(v)) }
+func (arr *logfmtArrayEncoder) AppendUint8(v uint8)     { arr.AppendUint64(uint64(v)) }
+func (arr *logfmtArrayEncoder) AppendUintptr(v uintptr) { arr.AppendUint64(uint64(v)) }
+
+func (arr *logfmtArrayEncoder) AppendUint64(v uint64) {
+	arr.separate()
+	arr.buf.AppendUint(v)
+}
+
+// logfmtKeyRune tests if a rune may appear in a logfmt key as is.
+func logfmtKeyRune(r rune) bool {
+	return r > ' ' && r != '=' && r != '"' && r != utf8.RuneError && unicode.IsPrint(r)
+}
+
+// appendLogfmtKey writes a key, replacing any characters that are not allowed
+// in logfmt keys with underscores.
+func appendLogfmtKey(b *buffer.Buffer, key string) {
+	for _, r := range key {
+		if logfmtKeyRune(r) {
+			b.AppendString(string(r))
+		} else {
+			b.AppendByte('_')
+		}
+	}
+}
+
+// logfmtNeedsQuotes tests if a value must be quoted.
+func logfmtNeedsQuotes(v string) bool {
+	if len(v) == 0 {
+		return true
+	}
+
+	for _, r := range v {
+		if !logfmtKeyRune(r) || r == '\\' {
+			return true
+		}
+	}
+
+	return false
+}
+
+// appendLogfmtString writes a value, quoting it if necessary.
+func appendLogfmtString(b *buffer.Buffer, v string) {
+	if logfmtNeedsQuotes(v) {
+		b.AppendString(strconv.Quote(v))
+	} else {
+		b.AppendString(v)
+	}
+}
+
+// appendLogfmtFloat writes a float in the same format as zapcore's JSON encoder,
+// but without quoting the special values.
+func appendLogfmtFloat(b *buffer.Buffer, v float64, bitSize int) {
+	switch {
+	case math.IsNaN(v):
+		b.AppendString("NaN")
+	case math.IsInf(v, 1):
+		b.AppendString("+Inf")
+	case math.IsInf(v, -1):
+		b.AppendString("-Inf")
+	default:
+		b.AppendFloat(v, bitSize)
+	}
+}
+
+// appendLogfmtComplex writes a complex number as real+imaginary, e.g. 1+2i.
+func appendLogfmtComplex(b *buffer.Buffer, v complex128, bitSize int) {
+	r, i := real(v), imag(v)
+	appendLogfmtFloat(b, r, bitSize)
+	if (i >= 0 && !math.IsInf(i, 1)) || math.IsNaN(i) {
+		b.AppendByte('+')
+	}
+
+	appendLogfmtFloat(b, i, bitSize)
+	b.AppendByte('i')
+}
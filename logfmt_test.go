@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// testLogfmtEntry is the entry used to check the logfmt encoder's output.
+var testLogfmtEntry = zapcore.Entry{
+	Level:      zapcore.WarnLevel,
+	Time:       time.Date(2026, time.March, 14, 15, 9, 26, 0, time.UTC),
+	LoggerName: "service.handler",
+	Message:    "request failed",
+	Caller: zapcore.EntryCaller{
+		Defined:  true,
+		File:     "/src/service/handler.go",
+		Line:     42,
+		Function: "service.(*Handler).ServeHTTP",
+	},
+	Stack: "goroutine 1",
+}
+
+type testLogfmtObject struct{}
+
+func (testLogfmtObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("method", "GET")
+	enc.OpenNamespace("headers")
+	enc.AddString("accept", "*/*")
+	return nil
+}
+
+func testLogfmtEncode(t *testing.T, cfg zapcore.EncoderConfig, ent zapcore.Entry, fields ...zapcore.Field) string {
+	b, err := NewLogfmtEncoder(cfg).EncodeEntry(ent, fields)
+	require.NoError(t, err)
+	defer b.Free()
+	return b.String()
+}
+
+func testLogfmtEncoderConfig(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	cfg, err := EncoderConfig{
+		TimeKey:       "ts",
+		LevelKey:      "level",
+		NameKey:       "logger",
+		CallerKey:     "caller",
+		FunctionKey:   "func",
+		MessageKey:    "msg",
+		StacktraceKey: "stack",
+		EncodeLevel:   "capital",
+		EncodeTime:    "RFC3339",
+		EncodeCaller:  "short",
+	}.NewZapcoreEncoderConfig()
+
+	require.NoError(err)
+	assert.Equal(
+		`ts=2026-03-14T15:09:26Z level=WARN logger=service.handler caller=service/handler.go:42 `+
+			`func=service.(*Handler).ServeHTTP msg="request failed" stack="goroutine 1"`+"\n",
+		testLogfmtEncode(t, cfg, testLogfmtEntry),
+	)
+
+	// empty keys omit their part of the entry
+	cfg.TimeKey = ""
+	cfg.NameKey = ""
+	cfg.CallerKey = ""
+	cfg.FunctionKey = ""
+	cfg.StacktraceKey = ""
+	cfg.LineEnding = "\r\n"
+	cfg.EncodeLevel = zapcore.LowercaseLevelEncoder
+	assert.Equal(
+		"level=warn msg=\"request failed\"\r\n",
+		testLogfmtEncode(t, cfg, testLogfmtEntry),
+	)
+
+	cfg.SkipLineEnding = true
+	cfg.EncodeTime = nil
+	cfg.TimeKey = "ts"
+	assert.Equal(
+		`ts=1773500966000000000 level=warn msg="request failed"`,
+		testLogfmtEncode(t, cfg, testLogfmtEntry),
+	)
+}
+
+func testLogfmtFields(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		cfg    = zapcore.EncoderConfig{
+			MessageKey:     "msg",
+			EncodeDuration: zapcore.StringDurationEncoder,
+			SkipLineEnding: true,
+		}
+	)
+
+	assert.Equal(
+		`msg=fields s=plain q="two words" e="" eq="a=b" quote="say \"hi\"" n=-12 u=7 f=1.5 nan=NaN `+
+			`c=1-2i b=true d=1.5s bin="AQI=" ints=[1,2,3] strs="[a,\"b c\",\"d,e\"]" `+
+			`objs="[{method=GET headers.accept=*/*}]" obj.method=GET obj.headers.accept=*/* `+
+			`r="{\"k\":[1,2]}" error=boom bad_key=x`,
+		testLogfmtEncode(
+			t,
+			cfg,
+			zapcore.Entry{Message: "fields"},
+			zap.String("s", "plain"),
+			zap.String("q", "two words"),
+			zap.String("e", ""),
+			zap.String("eq", "a=b"),
+			zap.String("quote", `say "hi"`),
+			zap.Int("n", -12),
+			zap.Uint("u", 7),
+			zap.Float64("f", 1.5),
+			zap.Float64("nan", math.NaN()),
+			zap.Complex128("c", complex(1, -2)),
+			zap.Bool("b", true),
+			zap.Duration("d", 1500*time.Millisecond),
+			zap.Binary("bin", []byte{1, 2}),
+			zap.Ints("ints", []int{1, 2, 3}),
+			zap.Strings("strs", []string{"a", "b c", "d,e"}),
+			zap.Objects("objs", []testLogfmtObject{{}}),
+			zap.Object("obj", testLogfmtObject{}),
+			zap.Reflect("r", map[string][]int{"k": {1, 2}}),
+			zap.Error(errors.New("boom")),
+			zap.String("bad key", "x"),
+		),
+	)
+}
+
+func testLogfmtContext(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		enc    = NewLogfmtEncoder(zapcore.EncoderConfig{
+			MessageKey:    "msg",
+			StacktraceKey: "stack",
+		})
+	)
+
+	enc.AddString("service", "api")
+	enc.OpenNamespace("request")
+
+	clone := enc.Clone()
+	clone.AddString("id", "123")
+
+	b, err := clone.EncodeEntry(
+		zapcore.Entry{Message: "done", Stack: "trace"},
+		[]zapcore.Field{zap.Int("status", 200)},
+	)
+
+	require.NoError(t, err)
+	assert.Equal("msg=done service=api request.id=123 request.status=200 stack=trace\n", b.String())
+
+	// the original encoder is unaffected by the clone
+	b, err = enc.EncodeEntry(zapcore.Entry{Message: "original"}, nil)
+	require.NoError(t, err)
+	assert.Equal("msg=original service=api\n", b.String())
+}
+
+func testLogfmtConfig(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		file    = filepath.Join(t.TempDir(), "logfmt.log")
+	)
+
+	c := Config{
+		Encoding:    LogfmtEncoding,
+		OutputPaths: []string{file},
+		EncoderConfig: EncoderConfig{
+			LevelKey:    "lvl",
+			MessageKey:  "message",
+			EncodeLevel: "lowercase",
+		},
+	}
+
+	require.NoError(c.Validate())
+	l, err := c.Build()
+	require.NoError(err)
+	l.Info("hello world", zap.String("user", "alice"))
+	require.NoError(l.Sync())
+
+	contents, err := os.ReadFile(file)
+	require.NoError(err)
+	assert.Regexp(`^ts=\S+ lvl=info message="hello world" user=alice\n$`, string(contents))
+}
+
+func TestLogfmt(t *testing.T) {
+	t.Run("EncoderConfig", testLogfmtEncoderConfig)
+	t.Run("Fields", testLogfmtFields)
+	t.Run("Context", testLogfmtContext)
+	t.Run("Config", testLogfmtConfig)
+}
@@ -4,10 +4,13 @@
 package sallust
 
 import (
+	"fmt"
 	"net/url"
 	"strconv"
+	"time"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
@@ -29,6 +32,15 @@ const (
 
 	// CompressParameter is the URL parameter that corresponds to lumberjack.Logger.Compress
 	CompressParameter = "compress"
+
+	// ScheduleParameter is the URL parameter that corresponds to Rotation.Schedule
+	ScheduleParameter = "schedule"
+
+	// FsyncParameter is the URL parameter that corresponds to Rotation.Fsync
+	FsyncParameter = "fsync"
+
+	// FsyncLevelParameter is the URL parameter that corresponds to Rotation.FsyncLevel
+	FsyncLevelParameter = "fsyncLevel"
 )
 
 func init() {
@@ -43,14 +55,18 @@ type Rotater interface {
 // Rotation describes the set of configurable options for log file rotation.
 // This configuration, if supplied, is only applied to file outputs.
 //
-// The fields in this struct correspond exactly to lumberjack.Logger.
+// Except for Schedule, Fsync, and FsyncLevel, the fields in this struct correspond exactly to lumberjack.Logger.
 //
 // See: https://pkg.go.dev/gopkg.in/natefinch/lumberjack.v2?tab=doc#Logger
 type Rotation struct {
-	// MaxSize corresponds to lumberjack.Logger.MaxSize
+	// MaxSize corresponds to lumberjack.Logger.MaxSize.  When decoded from JSON, YAML, or
+	// with DecodeHook, this may be a number of megabytes or a size with units such as
+	// "250MiB" or "1.5GB", as accepted by ParseMegabytes.
 	MaxSize int `json:"maxsize" yaml:"maxsize"`
 
-	// MaxAge corresponds to lumberjack.Logger.MaxAge
+	// MaxAge corresponds to lumberjack.Logger.MaxAge.  When decoded from JSON, YAML, or
+	// with DecodeHook, this may be a number of days or an age with units such as "14d" or
+	// "36h", as accepted by ParseDays.
 	MaxAge int `json:"maxage" yaml:"maxage"`
 
 	// MaxBackups corresponds to lumberjack.Logger.MaxBackups
@@ -61,6 +77,22 @@ type Rotation struct {
 
 	// Compress corresponds to lumberjack.Logger.Compress
 	Compress bool `json:"compress" yaml:"compress"`
+
+	// Schedule is the optional time-based rotation schedule, as accepted by ParseSchedule.
+	// A schedule can be combined with MaxSize, in which case a file is rotated whenever
+	// either limit is reached.  Schedule boundaries are in local time if LocalTime is set,
+	// and UTC otherwise.
+	Schedule string `json:"schedule" yaml:"schedule"`
+
+	// Fsync makes Sync flush the current file to stable storage, so that synced entries
+	// survive a power loss.  Without it, Sync is a nop and entries reach the disk whenever
+	// the operating system writes them out.
+	Fsync bool `json:"fsync" yaml:"fsync"`
+
+	// FsyncLevel is the optional level at or above which each entry is flushed to stable
+	// storage as soon as it is written.  Setting this field implies Fsync.  Because the flush
+	// is done by syncing the logger's core, the other outputs of the core are synced as well.
+	FsyncLevel string `json:"fsynclevel" yaml:"fsynclevel"`
 }
 
 // AddQueryValues adds the set of URL query parameters for these Rotation options
@@ -84,6 +116,18 @@ func (r Rotation) AddQueryValues(v url.Values) {
 	if r.Compress {
 		v.Set(CompressParameter, strconv.FormatBool(r.Compress))
 	}
+
+	if len(r.Schedule) > 0 {
+		v.Set(ScheduleParameter, r.Schedule)
+	}
+
+	if r.Fsync {
+		v.Set(FsyncParameter, strconv.FormatBool(r.Fsync))
+	}
+
+	if len(r.FsyncLevel) > 0 {
+		v.Set(FsyncLevelParameter, r.FsyncLevel)
+	}
 }
 
 // NewURL creates a URL object that represents a lumberjack-rotatable file
@@ -102,68 +146,174 @@ func (r Rotation) NewURL(path string) *url.URL {
 // Lumberjack is a zap.Sink adapter that writes to a lumberjack Logger.
 // This type also implements Rotater.
 //
+// Lumberjack sinks created by NewLumberjackSink are tracked by the process-wide
+// LumberjackRegistry, and all such sinks for the same file share one lumberjack.Logger
+// and must have the same rotation options.
+// The registry's OnRotation hooks are told about rotations and about backups that are
+// compressed or removed.
+//
+// If the sink has a rotation schedule, the file is rotated by the first write at or
+// after each of the schedule's boundaries.  A file left over from before the most
+// recent boundary is rotated before it is first written to.
+//
 // A Lumberjack is safe for concurrent writes.  No additional synchronization
 // is required.
 type Lumberjack struct {
+	// Logger is the lumberjack.Logger shared by all the sinks for the file.  It is
+	// read-only:  changing its fields, or writing to it directly, bypasses the sharing
+	// and rotation events that the registry provides.
 	*lumberjack.Logger
+
+	ref *lumberjackRef
+
+	// fsync is set if Sync flushes the file to stable storage
+	fsync bool
+
+	// fsyncLevel is the lowest level of the entries that are flushed as soon as they are written
+	fsyncLevel *zapcore.Level
 }
 
 var _ zap.Sink = Lumberjack{}
 var _ Rotater = Lumberjack{}
 
-// Sync is a nop, and implements zapcore.WriteSyncer
+// Write rotates the file if the schedule calls for it, then writes p to the file.
+func (lj Lumberjack) Write(p []byte) (int, error) {
+	if lj.ref == nil {
+		return lj.Logger.Write(p)
+	}
+
+	return lj.ref.file.write(p)
+}
+
+// Rotate rotates the file, which is shared with any other sinks writing to it.
+func (lj Lumberjack) Rotate() error {
+	if lj.ref == nil {
+		return lj.Logger.Rotate()
+	}
+
+	return lj.ref.file.rotate()
+}
+
+// Close releases this sink's use of the file.  The file is closed once all the
+// sinks writing to it are closed.
+func (lj Lumberjack) Close() error {
+	if lj.ref == nil {
+		return lj.Logger.Close()
+	}
+
+	return lj.ref.release()
+}
+
+// Sync flushes the file to stable storage if this sink was opened with the fsync option.
+// Otherwise, Sync is a nop, as lumberjack does not buffer writes.
 func (lj Lumberjack) Sync() error {
-	return nil
+	if lj.ref == nil || !lj.fsync {
+		return nil
+	}
+
+	return lj.ref.file.sync()
 }
 
-// NewLumberjackSink creates a zap.Sink which rotates its corresponding file.
-// This packages registers this a factory with zap.RegisterSink.
-func NewLumberjackSink(u *url.URL) (zap.Sink, error) {
-	lj := Lumberjack{
-		Logger: &lumberjack.Logger{
-			Filename: u.Path,
-		},
+// lumberjackURL holds the options parsed from a lumberjack URL.
+type lumberjackURL struct {
+	logger     *lumberjack.Logger
+	schedule   *rotationSchedule
+	fsync      bool
+	fsyncLevel *zapcore.Level
+}
+
+// parseLumberjackURL parses a lumberjack URL without opening the file.
+func parseLumberjackURL(u *url.URL) (lu lumberjackURL, err error) {
+	lu.logger = &lumberjack.Logger{
+		Filename: u.Path,
 	}
 
 	values, err := url.ParseQuery(u.RawQuery)
 	if err != nil {
-		return nil, err
+		return
 	}
 
-	if v := values.Get(MaxSizeParameter); len(v) > 0 {
-		lj.MaxSize, err = strconv.Atoi(v)
-		if err != nil {
-			return nil, err
-		}
+	invalid := func(name, value string) error {
+		return fmt.Errorf("Invalid lumberjack %s [%s]", name, value) // nolint:staticcheck
 	}
 
-	if v := values.Get(MaxAgeParameter); len(v) > 0 {
-		lj.MaxAge, err = strconv.Atoi(v)
-		if err != nil {
-			return nil, err
+	for _, p := range []struct {
+		name  string
+		parse func(string) (int, error)
+		value *int
+	}{
+		{MaxSizeParameter, ParseMegabytes, &lu.logger.MaxSize},
+		{MaxAgeParameter, ParseDays, &lu.logger.MaxAge},
+		{MaxBackupsParameter, strconv.Atoi, &lu.logger.MaxBackups},
+	} {
+		if v := values.Get(p.name); len(v) > 0 {
+			*p.value, err = p.parse(v)
+			if err != nil || *p.value < 0 {
+				return lu, invalid(p.name, v)
+			}
 		}
 	}
 
-	if v := values.Get(MaxBackupsParameter); len(v) > 0 {
-		lj.MaxBackups, err = strconv.Atoi(v)
-		if err != nil {
-			return nil, err
+	for _, p := range []struct {
+		name  string
+		value *bool
+	}{
+		{LocalTimeParameter, &lu.logger.LocalTime},
+		{CompressParameter, &lu.logger.Compress},
+		{FsyncParameter, &lu.fsync},
+	} {
+		if v := values.Get(p.name); len(v) > 0 {
+			*p.value, err = strconv.ParseBool(v)
+			if err != nil {
+				return lu, invalid(p.name, v)
+			}
 		}
 	}
 
-	if v := values.Get(LocalTimeParameter); len(v) > 0 {
-		lj.LocalTime, err = strconv.ParseBool(v)
+	if v := values.Get(ScheduleParameter); len(v) > 0 {
+		var interval time.Duration
+		interval, err = ParseSchedule(v)
 		if err != nil {
-			return nil, err
+			return
+		}
+
+		location := time.UTC
+		if lu.logger.LocalTime {
+			location = time.Local
 		}
+
+		lu.schedule = newRotationSchedule(interval, location)
 	}
 
-	if v := values.Get(CompressParameter); len(v) > 0 {
-		lj.Compress, err = strconv.ParseBool(v)
-		if err != nil {
-			return nil, err
+	if v := values.Get(FsyncLevelParameter); len(v) > 0 {
+		lu.fsyncLevel = new(zapcore.Level)
+		if err = lu.fsyncLevel.UnmarshalText([]byte(v)); err != nil {
+			return lu, invalid(FsyncLevelParameter, v)
 		}
+
+		lu.fsync = true
+	}
+
+	return
+}
+
+// NewLumberjackSink creates a zap.Sink which rotates its corresponding file.
+// This packages registers this a factory with zap.RegisterSink.
+//
+// The returned sink is tracked by the process-wide LumberjackRegistry until it is closed.
+// If other sinks are already writing to the same file, the URL must have the same rotation
+// options as theirs, or an error is returned.
+func NewLumberjackSink(u *url.URL) (zap.Sink, error) {
+	lu, err := parseLumberjackURL(u)
+	if err != nil {
+		return nil, err
+	}
+
+	sink, err := lumberjacks.open(lu.logger, lu.schedule)
+	if err != nil {
+		return nil, err
 	}
 
-	return lj, nil
+	sink.fsync, sink.fsyncLevel = lu.fsync, lu.fsyncLevel
+	return sink, nil
 }
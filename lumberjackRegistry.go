@@ -0,0 +1,460 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// errLumberjackClosed is returned for writes to a file closed through the LumberjackRegistry.
+var errLumberjackClosed = errors.New("lumberjack file is closed")
+
+// lumberjackName returns the absolute path of a lumberjack filename, without resolving symlinks.
+func lumberjackName(filename string) string {
+	if len(filename) == 0 {
+		// the same default lumberjack.Logger uses
+		filename = filepath.Join(os.TempDir(), filepath.Base(os.Args[0])+"-lumberjack.log")
+	}
+
+	name, err := filepath.Abs(filename)
+	if err != nil {
+		return filepath.Clean(filename)
+	}
+
+	return name
+}
+
+// lumberjackPath resolves a lumberjack filename to the absolute path of the file,
+// following any symlinks, so that different spellings of the same file share a key.
+func lumberjackPath(filename string) string {
+	path := lumberjackName(filename)
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+
+	// the file may not exist yet, but its directory might
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(path)); err == nil {
+		return filepath.Join(dir, filepath.Base(path))
+	}
+
+	return path
+}
+
+// sameRotation tests if two lumberjack Loggers and their schedules rotate in the same way.
+func sameRotation(l1 *lumberjack.Logger, rs1 *rotationSchedule, l2 *lumberjack.Logger, rs2 *rotationSchedule) bool {
+	if l1.MaxSize != l2.MaxSize || l1.MaxAge != l2.MaxAge || l1.MaxBackups != l2.MaxBackups ||
+		l1.LocalTime != l2.LocalTime || l1.Compress != l2.Compress {
+		return false
+	}
+
+	if rs1 == nil || rs2 == nil {
+		return rs1 == rs2
+	}
+
+	return rs1.interval == rs2.interval && rs1.location == rs2.location
+}
+
+// lumberjackFile is a file shared by all the Lumberjack sinks that write to it.
+//
+// The lumberjack.Logger does all the rotation, compression, and removal of backups.  This
+// type only observes it:  the writes that may rotate the file check whether the file was
+// moved aside, and if lumberjack compresses or removes backups, a watch goroutine follows
+// the backups while lumberjack mills them.
+type lumberjackFile struct {
+	registry *LumberjackRegistry
+	path     string
+
+	lock     sync.Mutex
+	logger   *lumberjack.Logger
+	schedule *rotationSchedule
+	refs     int
+	closed   bool
+
+	// opened is set once the logger has opened the file, which is when lumberjack first
+	// mills any backups left from before
+	opened bool
+
+	// size estimates the size of the file, to tell which writes may rotate it
+	size int64
+
+	// backups are the backups followed by the watch goroutine, and gen orders their
+	// discovery against that goroutine's listings
+	backups map[string]trackedBackup
+	gen     uint64
+
+	poked    bool
+	watching bool
+
+	// done is closed when the file is closed, which stops the watch goroutine
+	done chan struct{}
+
+	// rotated are the rotations made while the lock is held, which are reported
+	// once it is released
+	rotated []RotationEvent
+}
+
+// max returns the size at which lumberjack rotates the file, which defaults to 100 megabytes.
+func (lf *lumberjackFile) max() int64 {
+	if lf.logger.MaxSize == 0 {
+		return 100 * megabyte
+	}
+
+	return int64(lf.logger.MaxSize) * megabyte
+}
+
+// open notes the backups that exist before lumberjack first opens the file and mills them.
+// The lock must be held.
+func (lf *lumberjackFile) open() {
+	if lf.opened {
+		return
+	}
+
+	lf.opened = true
+	lf.size = 0
+	if fi, err := os.Stat(lf.path); err == nil {
+		lf.size = fi.Size()
+	}
+
+	if lf.milling() {
+		if current, err := backupStates(lumberjackName(lf.logger.Filename)); err == nil {
+			lf.track(current)
+		}
+
+		lf.poke()
+	}
+}
+
+// observe calls f, which may rotate the file, and records the rotation if one happened.
+// The lock must be held.
+func (lf *lumberjackFile) observe(f func() (int, error)) (int, error) {
+	var (
+		filename    = lumberjackName(lf.logger.Filename)
+		old, oldErr = os.Lstat(filename)
+		start       = time.Now()
+		n, err      = f()
+		end         = time.Now()
+	)
+
+	if oldErr != nil {
+		// lumberjack only rotates a file that exists
+		lf.size += int64(n)
+		return n, err
+	}
+
+	if current, err := os.Lstat(filename); err == nil && os.SameFile(old, current) {
+		lf.size += int64(n)
+		return n, err
+	}
+
+	lf.size = int64(n)
+	if backup := rotationBackup(filename, old, start, end, lf.logger.LocalTime); len(backup) > 0 {
+		lf.rotated = append(lf.rotated, RotationEvent{
+			Type:     RotationRotated,
+			Filename: filename,
+			Backup:   backup,
+		})
+	}
+
+	return n, err
+}
+
+// unlock releases the lock, and then reports any rotations made while it was held.  Rotations
+// are reported by the goroutine that made them, but never while the lock is held.
+func (lf *lumberjackFile) unlock() {
+	rotated := lf.rotated
+	lf.rotated = nil
+	lf.lock.Unlock()
+	if len(rotated) == 0 {
+		return
+	}
+
+	for _, e := range rotated {
+		lf.registry.dispatch(e)
+	}
+
+	// the new backups are only followed once their rotations have been reported, so
+	// that the watch goroutine cannot report them first
+	lf.lock.Lock()
+	defer lf.lock.Unlock()
+	if !lf.closed && lf.milling() {
+		for _, e := range rotated {
+			// the backup starts out uncompressed, even if lumberjack has already compressed it
+			lf.track(map[string]backupState{e.Backup: backupPlain})
+		}
+
+		lf.poke()
+	}
+}
+
+// rotateLocked rotates the file.  The lock must be held.
+func (lf *lumberjackFile) rotateLocked() error {
+	lf.open()
+	_, err := lf.observe(func() (int, error) {
+		return 0, lf.logger.Rotate()
+	})
+
+	return err
+}
+
+func (lf *lumberjackFile) write(p []byte) (int, error) {
+	lf.lock.Lock()
+	defer lf.unlock()
+
+	if lf.closed {
+		return 0, errLumberjackClosed
+	}
+
+	lf.open()
+	if lf.schedule != nil {
+		if err := lf.schedule.check(lf.logger.Filename, lf.rotateLocked); err != nil {
+			return 0, err
+		}
+	}
+
+	size := int64(len(p))
+	if size <= lf.max() && lf.size+size >= lf.max() {
+		// lumberjack will rotate the file before writing
+		return lf.observe(func() (int, error) {
+			return lf.logger.Write(p)
+		})
+	}
+
+	n, err := lf.logger.Write(p)
+	lf.size += int64(n)
+	return n, err
+}
+
+func (lf *lumberjackFile) rotate() error {
+	lf.lock.Lock()
+	defer lf.unlock()
+
+	if lf.closed {
+		return errLumberjackClosed
+	}
+
+	return lf.rotateLocked()
+}
+
+// sync flushes the file to stable storage.  lumberjack does not expose its file handle, so
+// the file is opened again for the flush.  A file that has not been created yet is ignored.
+func (lf *lumberjackFile) sync() error {
+	lf.lock.Lock()
+	defer lf.lock.Unlock()
+
+	if lf.closed {
+		return errLumberjackClosed
+	}
+
+	f, err := os.OpenFile(lf.path, os.O_WRONLY, 0)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	err = f.Sync()
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// lumberjackRef is one Lumberjack sink's reference to a shared file.
+type lumberjackRef struct {
+	registry *LumberjackRegistry
+	file     *lumberjackFile
+	once     sync.Once
+}
+
+// release gives up this reference.  Only the first call has any effect.
+func (ref *lumberjackRef) release() (err error) {
+	ref.once.Do(func() {
+		err = ref.registry.release(ref.file)
+	})
+
+	return
+}
+
+// LumberjackRegistry tracks the files written by the Lumberjack sinks that sallust creates,
+// keyed by the absolute path of each file.  Because NewLumberjackSink is normally invoked by
+// zap, this registry is how an application reaches those sinks.
+//
+// All the sinks for the same file share a single lumberjack.Logger, so that two writers never
+// rotate the same file.  A sink whose rotation options differ from those of the sinks already
+// writing to its file cannot be opened.  To change the rotation of a file, for example with a
+// Reloader, every sink for that file must be closed before the new sink is opened.
+type LumberjackRegistry struct {
+	lock  sync.Mutex
+	files map[string]*lumberjackFile
+
+	hooksLock sync.Mutex
+	hooks     []*rotationHook
+}
+
+// lumberjacks is the process-wide registry used by NewLumberjackSink.
+var lumberjacks = newLumberjackRegistry()
+
+func newLumberjackRegistry() *LumberjackRegistry {
+	return &LumberjackRegistry{
+		files: make(map[string]*lumberjackFile),
+	}
+}
+
+// Lumberjacks returns the process-wide registry of the files written by Lumberjack sinks.
+func Lumberjacks() *LumberjackRegistry {
+	return lumberjacks
+}
+
+// open registers a new Lumberjack sink for the logger's file.  If the file is already open,
+// the new sink shares the existing lumberjack.Logger, and an error is returned if the new
+// sink's rotation options differ from it.
+func (lr *LumberjackRegistry) open(logger *lumberjack.Logger, schedule *rotationSchedule) (Lumberjack, error) {
+	path := lumberjackPath(logger.Filename)
+
+	lr.lock.Lock()
+	defer lr.lock.Unlock()
+
+	lf, ok := lr.files[path]
+	if !ok {
+		lf = &lumberjackFile{
+			registry: lr,
+			path:     path,
+			logger:   logger,
+			schedule: schedule,
+			done:     make(chan struct{}),
+		}
+
+		lr.files[path] = lf
+	}
+
+	lf.lock.Lock()
+	defer lf.lock.Unlock()
+
+	if !sameRotation(lf.logger, lf.schedule, logger, schedule) {
+		return Lumberjack{}, fmt.Errorf("Conflicting lumberjack rotation options for [%s]", path) // nolint:staticcheck
+	}
+
+	lf.refs++
+	return Lumberjack{
+		Logger: lf.logger,
+		ref: &lumberjackRef{
+			registry: lr,
+			file:     lf,
+		},
+	}, nil
+}
+
+// release drops one sink's reference to a file, closing the file when no sinks remain.
+func (lr *LumberjackRegistry) release(lf *lumberjackFile) error {
+	lr.lock.Lock()
+	defer lr.lock.Unlock()
+
+	lf.lock.Lock()
+	defer lf.lock.Unlock()
+
+	lf.refs--
+	if lf.refs > 0 || lf.closed {
+		return nil
+	}
+
+	return lr.close(lf)
+}
+
+// close closes a file and removes it from this registry.  Both locks must be held.
+// The watch goroutine, if any, reports what lumberjack has done to the backups so far,
+// and then stops.
+func (lr *LumberjackRegistry) close(lf *lumberjackFile) error {
+	if !lf.closed {
+		lf.closed = true
+		close(lf.done)
+	}
+
+	delete(lr.files, lf.path)
+	return lf.logger.Close()
+}
+
+// lookup returns the file registered for a path.  The registry lock must be held.
+func (lr *LumberjackRegistry) lookup(path string) (*lumberjackFile, error) {
+	lf, ok := lr.files[lumberjackPath(path)]
+	if !ok {
+		return nil, fmt.Errorf("No lumberjack file at [%s]", path) // nolint:staticcheck
+	}
+
+	return lf, nil
+}
+
+// List returns the sorted absolute paths of the files currently open.
+func (lr *LumberjackRegistry) List() []string {
+	lr.lock.Lock()
+	defer lr.lock.Unlock()
+
+	paths := make([]string, 0, len(lr.files))
+	for path := range lr.files {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+	return paths
+}
+
+// Rotate rotates the file at the given path.  The path may be given in any form that
+// resolves to the same file, and an error is returned if no such file is open.
+func (lr *LumberjackRegistry) Rotate(path string) error {
+	lr.lock.Lock()
+	lf, err := lr.lookup(path)
+	lr.lock.Unlock()
+
+	if err == nil {
+		err = lf.rotate()
+	}
+
+	return err
+}
+
+// RotateAll rotates every open file, returning the errors from any files that
+// could not be rotated.
+func (lr *LumberjackRegistry) RotateAll() error {
+	lr.lock.Lock()
+	files := make([]*lumberjackFile, 0, len(lr.files))
+	for _, lf := range lr.files {
+		files = append(files, lf)
+	}
+
+	lr.lock.Unlock()
+
+	var errs []error
+	for _, lf := range files {
+		if err := lf.rotate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", lf.path, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close closes the file at the given path and removes it from this registry.  Any sinks still
+// writing to the file return errors from then on.  An error is returned if no such file is open.
+func (lr *LumberjackRegistry) Close(path string) error {
+	lr.lock.Lock()
+	defer lr.lock.Unlock()
+
+	lf, err := lr.lookup(path)
+	if err != nil {
+		return err
+	}
+
+	lf.lock.Lock()
+	defer lf.lock.Unlock()
+	return lr.close(lf)
+}
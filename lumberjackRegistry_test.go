@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// testLumberjackSink opens a Lumberjack sink through the process-wide registry.
+func testLumberjackSink(t *testing.T, path string, r Rotation) Lumberjack {
+	sink, err := NewLumberjackSink(r.NewURL(path))
+	require.NoError(t, err)
+	return sink.(Lumberjack)
+}
+
+// testLumberjackOpen opens a Lumberjack sink without a schedule through the given registry.
+func testLumberjackOpen(t *testing.T, lr *LumberjackRegistry, logger *lumberjack.Logger) Lumberjack {
+	lj, err := lr.open(logger, nil)
+	require.NoError(t, err)
+	return lj
+}
+
+func testLumberjackRegistryShared(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		dir     = t.TempDir()
+		path    = filepath.Join(dir, "shared.log")
+		link    = filepath.Join(dir, "link")
+	)
+
+	require.NoError(os.Symlink(dir, link))
+	path = lumberjackPath(path)
+
+	first := testLumberjackSink(t, path, Rotation{MaxSize: 10})
+	second := testLumberjackSink(t, filepath.Join(link, ".", "shared.log"), Rotation{MaxSize: 10})
+	assert.Same(first.Logger, second.Logger)
+	assert.Contains(Lumberjacks().List(), path)
+
+	_, err := first.Write([]byte("first\n"))
+	require.NoError(err)
+	require.NoError(Lumberjacks().Rotate(filepath.Join(link, "shared.log")))
+	_, err = second.Write([]byte("second\n"))
+	require.NoError(err)
+
+	assert.Len(testBackups(t, path), 1)
+	assert.Equal("second\n", testReadFile(t, path))
+
+	// the file stays open until every sink is closed
+	require.NoError(first.Close())
+	require.NoError(first.Close())
+	assert.Contains(Lumberjacks().List(), path)
+	_, err = second.Write([]byte("still open\n"))
+	require.NoError(err)
+
+	require.NoError(second.Close())
+	assert.NotContains(Lumberjacks().List(), path)
+	assert.Error(Lumberjacks().Rotate(path))
+}
+
+func testLumberjackRegistryConflictingRotation(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		path    = lumberjackPath(filepath.Join(t.TempDir(), "conflicting.log"))
+	)
+
+	old := testLumberjackSink(t, path, Rotation{MaxSize: 10})
+	_, err := old.Write([]byte("old\n"))
+	require.NoError(err)
+
+	// a sink with different options cannot share the file
+	sink, err := NewLumberjackSink(Rotation{MaxSize: 20, MaxBackups: 3}.NewURL(path))
+	assert.Nil(sink)
+	assert.Error(err)
+
+	_, err = old.Write([]byte("after\n"))
+	require.NoError(err)
+	assert.Equal("old\nafter\n", testReadFile(t, path))
+	assert.Equal(10, old.ref.file.logger.MaxSize)
+
+	// once the file is closed, it can be opened with other options
+	require.NoError(old.Close())
+	current := testLumberjackSink(t, path, Rotation{MaxSize: 20, MaxBackups: 3})
+	defer current.Close()
+	assert.Equal(20, current.ref.file.logger.MaxSize)
+}
+
+func testLumberjackRegistryClose(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		path    = lumberjackPath(filepath.Join(t.TempDir(), "close.log"))
+		lj      = testLumberjackSink(t, path, Rotation{})
+	)
+
+	_, err := lj.Write([]byte("before\n"))
+	require.NoError(err)
+	require.NoError(Lumberjacks().Close(path))
+	assert.NotContains(Lumberjacks().List(), path)
+	assert.Error(Lumberjacks().Close(path))
+
+	_, err = lj.Write([]byte("after\n"))
+	assert.ErrorIs(err, errLumberjackClosed)
+	assert.ErrorIs(lj.Rotate(), errLumberjackClosed)
+	assert.NoError(lj.Close())
+	assert.Equal("before\n", testReadFile(t, path))
+
+	// a new sink for the same path opens the file again
+	reopened := testLumberjackSink(t, path, Rotation{})
+	defer reopened.Close()
+	assert.Contains(Lumberjacks().List(), path)
+}
+
+func testLumberjackRegistryRotateAll(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		dir     = t.TempDir()
+		lr      = newLumberjackRegistry()
+		first   = lumberjackPath(filepath.Join(dir, "first.log"))
+		second  = lumberjackPath(filepath.Join(dir, "second.log"))
+	)
+
+	for _, path := range []string{second, first} {
+		lj := testLumberjackOpen(t, lr, &lumberjack.Logger{Filename: path})
+		defer lj.Close()
+		_, err := lj.Write([]byte("before\n"))
+		require.NoError(err)
+	}
+
+	assert.Equal([]string{first, second}, lr.List())
+	require.NoError(lr.RotateAll())
+	assert.Len(testBackups(t, first), 1)
+	assert.Len(testBackups(t, second), 1)
+
+	require.NoError(lr.Close(second))
+	assert.Equal([]string{first}, lr.List())
+}
+
+func testLumberjackRegistryURL(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		path    = filepath.Join(t.TempDir(), "url.log")
+	)
+
+	l, _, res, err := Config{
+		OutputPaths: []string{
+			(&Rotation{MaxSize: 10}).NewURL(path).String(),
+			(&url.URL{Scheme: LumberjackScheme, Path: path, RawQuery: "maxSize=10"}).String(),
+		},
+	}.build()
+
+	require.NoError(err)
+	l.Info("shared")
+	assert.Contains(Lumberjacks().List(), lumberjackPath(path))
+	assert.Equal(2, strings.Count(testReadFile(t, path), "shared"))
+
+	res.close()
+	assert.NotContains(Lumberjacks().List(), lumberjackPath(path))
+}
+
+func TestLumberjackRegistry(t *testing.T) {
+	t.Run("Shared", testLumberjackRegistryShared)
+	t.Run("ConflictingRotation", testLumberjackRegistryConflictingRotation)
+	t.Run("Close", testLumberjackRegistryClose)
+	t.Run("RotateAll", testLumberjackRegistryRotateAll)
+	t.Run("URL", testLumberjackRegistryURL)
+}
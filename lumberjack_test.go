@@ -6,11 +6,13 @@ package sallust
 import (
 	"encoding/json"
 	"net/url"
+	"path/filepath"
 	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
@@ -40,6 +42,9 @@ func testRotationAddQueryValues(t *testing.T) {
 				MaxBackups: 483,
 				LocalTime:  true,
 				Compress:   true,
+				Schedule:   ScheduleDaily,
+				Fsync:      true,
+				FsyncLevel: "error",
 			},
 			expected: url.Values{
 				MaxAgeParameter:     []string{"156"},
@@ -47,6 +52,9 @@ func testRotationAddQueryValues(t *testing.T) {
 				MaxBackupsParameter: []string{"483"},
 				LocalTimeParameter:  []string{"true"},
 				CompressParameter:   []string{"true"},
+				ScheduleParameter:   []string{"daily"},
+				FsyncParameter:      []string{"true"},
+				FsyncLevelParameter: []string{"error"},
 			},
 		},
 	}
@@ -126,8 +134,47 @@ func testLumberjackSync(t *testing.T) {
 	})
 }
 
+func testLumberjackFsync(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		path    = filepath.Join(t.TempDir(), "fsync.log")
+	)
+
+	lj := testLumberjackSink(t, path, Rotation{Fsync: true})
+	defer lj.Close()
+	assert.True(lj.fsync)
+	assert.Nil(lj.fsyncLevel)
+
+	// a file that hasn't been created yet has nothing to flush
+	assert.NoError(lj.Sync())
+
+	_, err := lj.Write([]byte("durable\n"))
+	require.NoError(err)
+	assert.NoError(lj.Sync())
+
+	require.NoError(Lumberjacks().Close(path))
+	assert.ErrorIs(lj.Sync(), errLumberjackClosed)
+}
+
+func testLumberjackFsyncLevel(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		path   = filepath.Join(t.TempDir(), "fsyncLevel.log")
+		lj     = testLumberjackSink(t, path, Rotation{FsyncLevel: "warn"})
+	)
+
+	defer lj.Close()
+	assert.True(lj.fsync)
+	if assert.NotNil(lj.fsyncLevel) {
+		assert.Equal(zapcore.WarnLevel, *lj.fsyncLevel)
+	}
+}
+
 func TestLumberjack(t *testing.T) {
 	t.Run("Sync", testLumberjackSync)
+	t.Run("Fsync", testLumberjackFsync)
+	t.Run("FsyncLevel", testLumberjackFsyncLevel)
 }
 
 func testNewLumberjackSinkSuccess(t *testing.T) {
@@ -152,6 +199,14 @@ func testNewLumberjackSinkSuccess(t *testing.T) {
 				LocalTime:  true,
 			},
 		},
+		{
+			actualURL: "lumberjack:///units.json?maxAge=36h&maxSize=1.5GB",
+			expected: &lumberjack.Logger{
+				Filename: "/units.json",
+				MaxAge:   2,
+				MaxSize:  1430,
+			},
+		},
 	}
 
 	for i, record := range testData {
@@ -202,6 +257,10 @@ func testNewLumberjackSinkInvalidURL(t *testing.T) {
 			Path:     "/test",
 			RawQuery: "maxSize=thisisnotavalidint",
 		},
+		{
+			Path:     "/test",
+			RawQuery: "maxSize=100KB",
+		},
 		{
 			Path:     "/test",
 			RawQuery: "localTime=thisisnotavalidbool",
@@ -210,6 +269,18 @@ func testNewLumberjackSinkInvalidURL(t *testing.T) {
 			Path:     "/test",
 			RawQuery: "compress=thisisnotavalidbool",
 		},
+		{
+			Path:     "/test",
+			RawQuery: "schedule=weekly",
+		},
+		{
+			Path:     "/test",
+			RawQuery: "fsync=thisisnotavalidbool",
+		},
+		{
+			Path:     "/test",
+			RawQuery: "fsyncLevel=thisisnotavalidlevel",
+		},
 	}
 
 	for i := range testData {
@@ -0,0 +1,469 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// MemoryScheme is the URL scheme for keeping recent log entries in a named, in-memory
+	// ring buffer, e.g. memory://debug?size=5000.  The host is the name of the buffer, which
+	// can be retrieved with GetMemory.
+	MemoryScheme = "memory"
+
+	// MemorySizeParameter is the memory URL parameter for the number of entries kept.  Once
+	// the buffer is full, each new entry replaces the oldest.
+	MemorySizeParameter = "size"
+
+	// DefaultMemorySize is the number of entries kept when a memory URL has no
+	// MemorySizeParameter.
+	DefaultMemorySize = 1000
+)
+
+var (
+	memoriesLock sync.Mutex
+	memories     = map[string]*Memory{}
+)
+
+func init() {
+	registerSink(MemoryScheme, NewMemorySink)
+}
+
+// isMemoryScheme tests if a URL scheme is MemoryScheme.
+func isMemoryScheme(scheme string) bool {
+	return strings.EqualFold(scheme, MemoryScheme)
+}
+
+// MemoryEntry is a single log entry held by a Memory sink.
+type MemoryEntry struct {
+	// Time is the time of the entry.
+	Time time.Time
+
+	// Level is the level of the entry.
+	Level zapcore.Level
+
+	// LoggerName is the name of the logger that wrote the entry.
+	LoggerName string
+
+	// Message is the log message.
+	Message string
+
+	// Fields are the entry's fields, including any added with zap.Logger.With.  Fields
+	// added under a namespace are nested maps.
+	Fields map[string]interface{}
+
+	// Encoded is the entry as written by the logger's encoder.
+	Encoded []byte
+}
+
+// field looks up a field by key.  A key that is not found is split on periods, so
+// that fields beneath namespaces can be found.
+func (me MemoryEntry) field(key string) (interface{}, bool) {
+	if v, ok := me.Fields[key]; ok {
+		return v, true
+	}
+
+	var current interface{} = me.Fields
+	for _, segment := range strings.Split(key, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		if current, ok = m[segment]; !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// MemoryQuery selects entries from a Memory sink.  The zero value selects every entry.
+type MemoryQuery struct {
+	// Level, if set, excludes entries whose level it does not enable.  A zapcore.Level
+	// selects entries at or above that level.
+	Level zapcore.LevelEnabler
+
+	// Name, if set, selects entries from the logger with this name and its descendants.
+	Name string
+
+	// Since, if set, excludes entries before this time.
+	Since time.Time
+
+	// Until, if set, excludes entries after this time.
+	Until time.Time
+
+	// Fields selects entries that have each of these fields.  A field's value matches if
+	// its fmt.Sprint form is the given string.  Keys beneath a namespace are separated
+	// by periods.
+	Fields map[string]string
+
+	// Limit, if positive, is the maximum number of entries selected.  The most recent
+	// entries are preferred.
+	Limit int
+}
+
+// Match tests if an entry is selected by this query.  The Limit is not considered.
+func (mq MemoryQuery) Match(me MemoryEntry) bool {
+	switch {
+	case mq.Level != nil && !mq.Level.Enabled(me.Level):
+		return false
+
+	case len(mq.Name) > 0 && !matchesName(mq.Name, me.LoggerName):
+		return false
+
+	case !mq.Since.IsZero() && me.Time.Before(mq.Since):
+		return false
+
+	case !mq.Until.IsZero() && me.Time.After(mq.Until):
+		return false
+	}
+
+	for key, expected := range mq.Fields {
+		if v, ok := me.field(key); !ok || fmt.Sprint(v) != expected {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Memory is a zap.Sink that keeps the most recent log entries in a ring buffer.  Memory
+// sinks are named, and every memory URL with the same name shares the same buffer.  Closing
+// a Memory does not discard its entries, so they remain available across logger reloads.
+//
+// When a Config writes to a Memory, each entry retains its level, logger name, and fields
+// so that it can be queried.  Bytes written directly to a Memory are kept as informational
+// entries without a logger name or fields.
+//
+// A Memory is safe for concurrent use.  No additional synchronization is required.
+type Memory struct {
+	name string
+
+	lock    sync.Mutex
+	entries []MemoryEntry
+	head    int
+	count   int
+}
+
+var _ zap.Sink = (*Memory)(nil)
+
+// GetMemory returns the Memory with the given name, creating it with DefaultMemorySize
+// entries if necessary.  The result is the same Memory that memory URLs with this name
+// write to, whether or not the logger has been built yet.
+func GetMemory(name string) *Memory {
+	memoriesLock.Lock()
+	defer memoriesLock.Unlock()
+	return getMemory(name)
+}
+
+// getMemory returns the named Memory, creating it if necessary.  The memoriesLock must be held.
+func getMemory(name string) *Memory {
+	m, ok := memories[name]
+	if !ok {
+		m = &Memory{
+			name:    name,
+			entries: make([]MemoryEntry, DefaultMemorySize),
+		}
+
+		memories[name] = m
+	}
+
+	return m
+}
+
+// parseMemoryURL returns the name and size described by a memory URL.  The size is
+// zero if the URL does not specify one.
+func parseMemoryURL(u *url.URL) (name string, size int, err error) {
+	if !isMemoryScheme(u.Scheme) {
+		return "", 0, fmt.Errorf("Invalid memory scheme [%s]", u.Scheme) // nolint:staticcheck
+	}
+
+	name = u.Host
+	if len(name) == 0 {
+		return "", 0, fmt.Errorf("Invalid memory URL [%s]: no name", u) // nolint:staticcheck
+	}
+
+	if v := u.Query().Get(MemorySizeParameter); len(v) > 0 {
+		size, err = strconv.Atoi(v)
+		if err != nil || size < 1 {
+			return "", 0, fmt.Errorf("Invalid memory size [%s]", v) // nolint:staticcheck
+		}
+	}
+
+	return
+}
+
+// NewMemorySink returns the Memory named by the host of a memory URL, creating it if
+// necessary.  If the URL has a MemorySizeParameter, the Memory is resized, keeping its
+// most recent entries.  This package registers this factory with zap.RegisterSink for
+// MemoryScheme.
+func NewMemorySink(u *url.URL) (zap.Sink, error) {
+	name, size, err := parseMemoryURL(u)
+	if err != nil {
+		return nil, err
+	}
+
+	memoriesLock.Lock()
+	defer memoriesLock.Unlock()
+
+	m := getMemory(name)
+	if size > 0 {
+		m.resize(size)
+	}
+
+	return m, nil
+}
+
+// Name returns the name of this Memory.
+func (m *Memory) Name() string {
+	return m.name
+}
+
+// Size returns the maximum number of entries this Memory keeps.
+func (m *Memory) Size() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return len(m.entries)
+}
+
+// Len returns the number of entries this Memory currently holds.
+func (m *Memory) Len() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.count
+}
+
+// resize changes the number of entries kept, discarding the oldest ones if necessary.
+func (m *Memory) resize(size int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if size == len(m.entries) {
+		return
+	}
+
+	entries := make([]MemoryEntry, size)
+	skip := 0
+	if m.count > size {
+		skip = m.count - size
+	}
+
+	for i := skip; i < m.count; i++ {
+		entries[i-skip] = m.entries[(m.head+i)%len(m.entries)]
+	}
+
+	m.entries = entries
+	m.count -= skip
+	m.head = 0
+}
+
+// add stores an entry, replacing the oldest entry if this Memory is full.
+func (m *Memory) add(me MemoryEntry) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	i := (m.head + m.count) % len(m.entries)
+	m.entries[i] = me
+	if m.count < len(m.entries) {
+		m.count++
+	} else {
+		m.head = (m.head + 1) % len(m.entries)
+	}
+}
+
+// Entries returns the entries selected by a query, oldest first.  The returned entries
+// are shared with this Memory and must not be modified.
+func (m *Memory) Entries(mq MemoryQuery) []MemoryEntry {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var selected []MemoryEntry
+	for i := 0; i < m.count; i++ {
+		me := m.entries[(m.head+i)%len(m.entries)]
+		if mq.Match(me) {
+			selected = append(selected, me)
+		}
+	}
+
+	if mq.Limit > 0 && len(selected) > mq.Limit {
+		selected = selected[len(selected)-mq.Limit:]
+	}
+
+	return selected
+}
+
+// Write stores a copy of p as an informational entry.
+func (m *Memory) Write(p []byte) (int, error) {
+	m.add(MemoryEntry{
+		Time:    time.Now(),
+		Level:   zapcore.InfoLevel,
+		Encoded: append([]byte{}, p...),
+	})
+
+	return len(p), nil
+}
+
+// Sync does nothing, as entries are stored as soon as they are written.
+func (m *Memory) Sync() error {
+	return nil
+}
+
+// Close does nothing.  The entries remain available through GetMemory.
+func (m *Memory) Close() error {
+	return nil
+}
+
+// active always returns true, as a Memory keeps every entry written to it.
+func (m *Memory) active() bool {
+	return true
+}
+
+// entrySink is a zap.Sink that receives whole log entries, rather than just their encoding,
+// when written to by a Config.
+type entrySink interface {
+	zap.Sink
+
+	// add receives an entry.
+	add(MemoryEntry)
+
+	// active tests if this sink currently wants entries.  When it does not, entries
+	// are not encoded at all.
+	active() bool
+}
+
+// entryWriter is the zapcore.WriteSyncer for an entryCore.  The pending entry is the
+// one being encoded, and is guarded by the lock.
+type entryWriter struct {
+	sink    entrySink
+	lock    sync.Mutex
+	pending MemoryEntry
+}
+
+func (ew *entryWriter) Write(p []byte) (int, error) {
+	me := ew.pending
+	me.Encoded = append([]byte{}, p...)
+	ew.sink.add(me)
+	return len(p), nil
+}
+
+func (ew *entryWriter) Sync() error {
+	return ew.sink.Sync()
+}
+
+// entryCore writes each entry to an entrySink along with the entry's metadata and fields.
+type entryCore struct {
+	zapcore.Core
+
+	writer *entryWriter
+	fields []zapcore.Field
+}
+
+var _ zapcore.Core = entryCore{}
+
+// newEntryCore creates a core with the encoding of the given zap.Config that writes
+// to an entrySink.
+func newEntryCore(zc zap.Config, sink entrySink) (zapcore.Core, error) {
+	ew := &entryWriter{sink: sink}
+	core, err := newIOCore(zc, ew)
+	if err != nil {
+		return nil, err
+	}
+
+	return entryCore{
+		Core:   core,
+		writer: ew,
+	}, nil
+}
+
+func (ec entryCore) Enabled(l zapcore.Level) bool {
+	return ec.writer.sink.active() && ec.Core.Enabled(l)
+}
+
+func (ec entryCore) With(fields []zapcore.Field) zapcore.Core {
+	return entryCore{
+		Core:   ec.Core.With(fields),
+		writer: ec.writer,
+		fields: append(ec.fields[:len(ec.fields):len(ec.fields)], fields...),
+	}
+}
+
+func (ec entryCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ec.Enabled(ent.Level) {
+		return ce.AddCore(ent, ec)
+	}
+
+	return ce
+}
+
+// Write does nothing if the sink is not active, even when an enclosing core has already
+// checked the entry.
+func (ec entryCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if !ec.writer.sink.active() {
+		return nil
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range ec.fields {
+		f.AddTo(enc)
+	}
+
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	ec.writer.lock.Lock()
+	defer ec.writer.lock.Unlock()
+
+	ec.writer.pending = MemoryEntry{
+		Time:       ent.Time,
+		Level:      ent.Level,
+		LoggerName: ent.LoggerName,
+		Message:    ent.Message,
+		Fields:     enc.Fields,
+	}
+
+	return ec.Core.Write(ent, fields)
+}
+
+// isEntryScheme tests if a URL scheme is for one of the entrySink implementations.
+func isEntryScheme(scheme string) bool {
+	return isMemoryScheme(scheme) || isTailScheme(scheme)
+}
+
+// splitEntryPaths separates the memory and tail URLs from the other output paths, returning
+// the entrySink for each one.
+func splitEntryPaths(paths []string) (others []string, sinks []entrySink, err error) {
+	for _, p := range paths {
+		u, parseErr := url.Parse(p)
+		if parseErr != nil || !isEntryScheme(u.Scheme) {
+			others = append(others, p)
+			continue
+		}
+
+		var sink zap.Sink
+		if isMemoryScheme(u.Scheme) {
+			sink, err = NewMemorySink(u)
+		} else {
+			sink, err = NewTailSink(u)
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		sinks = append(sinks, sink.(entrySink))
+	}
+
+	return
+}
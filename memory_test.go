@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// testMemoryID distinguishes the memory sinks of repeated test runs.
+var testMemoryID atomic.Uint64
+
+// testMemoryName returns a memory sink name that has not been used before.
+func testMemoryName(prefix string) string {
+	return prefix + strconv.FormatUint(testMemoryID.Add(1), 10)
+}
+
+// testMemoryMessages returns the messages of a set of entries.
+func testMemoryMessages(entries []MemoryEntry) (messages []string) {
+	for _, me := range entries {
+		messages = append(messages, me.Message)
+	}
+
+	return
+}
+
+func testMemoryInvalid(t *testing.T) {
+	for _, path := range []string{
+		"memory://",
+		"memory://test?size=0",
+		"memory://test?size=many",
+		"tcp://localhost:5170",
+	} {
+		t.Run(path, func(t *testing.T) {
+			u, err := url.Parse(path)
+			require.NoError(t, err)
+
+			s, err := NewMemorySink(u)
+			assert.Nil(t, s)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func testMemoryRing(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	name := testMemoryName("ring")
+	sink, closer, err := zap.Open("memory://" + name + "?size=3")
+	require.NoError(err)
+	defer closer()
+
+	m := GetMemory(name)
+	assert.Equal(name, m.Name())
+	assert.Equal(3, m.Size())
+
+	for _, p := range []string{"one\n", "two\n", "three\n", "four\n"} {
+		_, err = sink.Write([]byte(p))
+		require.NoError(err)
+	}
+
+	assert.Equal(3, m.Len())
+	entries := m.Entries(MemoryQuery{})
+	require.Len(entries, 3)
+	assert.Equal("two\n", string(entries[0].Encoded))
+	assert.Equal("four\n", string(entries[2].Encoded))
+	assert.Equal(zapcore.InfoLevel, entries[0].Level)
+
+	// resizing keeps the most recent entries
+	_, closer2, err := zap.Open("memory://" + name + "?size=2")
+	require.NoError(err)
+	defer closer2()
+
+	entries = m.Entries(MemoryQuery{})
+	require.Len(entries, 2)
+	assert.Equal("three\n", string(entries[0].Encoded))
+	assert.Equal("four\n", string(entries[1].Encoded))
+}
+
+func testMemoryConfig(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		name    = testMemoryName("config")
+	)
+
+	l, err := Config{
+		Level:       "info",
+		OutputPaths: []string{Stdout},
+		Cores: []CoreConfig{
+			{
+				Level:       "debug",
+				OutputPaths: []string{"memory://" + name},
+			},
+		},
+		Permissions: "0600",
+	}.Build()
+
+	require.NoError(err)
+	l = l.With(zap.String("device", "mac:112233445566"))
+	l.Named("http").Debug("request", zap.Namespace("request"), zap.Int("status", 404))
+	l.Named("http.client").Warn("retry")
+	l.Named("db").Error("failed")
+
+	m := GetMemory(name)
+	entries := m.Entries(MemoryQuery{})
+	require.Len(entries, 3)
+	assert.Equal("request", entries[0].Message)
+	assert.Equal("http", entries[0].LoggerName)
+	assert.Equal(zapcore.DebugLevel, entries[0].Level)
+	assert.Equal("mac:112233445566", entries[0].Fields["device"])
+	assert.Contains(string(entries[0].Encoded), `"msg":"request"`)
+	assert.False(entries[0].Time.IsZero())
+
+	assert.Equal(
+		[]string{"retry", "failed"},
+		testMemoryMessages(m.Entries(MemoryQuery{Level: zapcore.WarnLevel})),
+	)
+
+	assert.Equal(
+		[]string{"request", "retry"},
+		testMemoryMessages(m.Entries(MemoryQuery{Name: "http"})),
+	)
+
+	assert.Equal(
+		[]string{"request"},
+		testMemoryMessages(m.Entries(MemoryQuery{
+			Fields: map[string]string{
+				"device":         "mac:112233445566",
+				"request.status": "404",
+			},
+		})),
+	)
+
+	assert.Empty(m.Entries(MemoryQuery{Fields: map[string]string{"request.status": "200"}}))
+	assert.Equal([]string{"failed"}, testMemoryMessages(m.Entries(MemoryQuery{Limit: 1})))
+	assert.Empty(m.Entries(MemoryQuery{Since: time.Now().Add(time.Hour)}))
+	assert.Empty(m.Entries(MemoryQuery{Until: time.Now().Add(-time.Hour)}))
+}
+
+func testMemoryValidate(t *testing.T) {
+	assert.Equal(
+		t,
+		[]string{"outputPaths[1]", "cores[0].outputPaths[0]"},
+		validationPaths(t, Config{
+			OutputPaths: []string{
+				"memory://valid?size=100",
+				"memory://invalid?size=-1",
+			},
+			Cores: []CoreConfig{
+				{OutputPaths: []string{"memory://"}},
+			},
+		}.Validate()),
+	)
+}
+
+func TestMemory(t *testing.T) {
+	t.Run("Invalid", testMemoryInvalid)
+	t.Run("Ring", testMemoryRing)
+	t.Run("Config", testMemoryConfig)
+	t.Run("Validate", testMemoryValidate)
+}
@@ -0,0 +1,426 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+var (
+	configType        = reflect.TypeOf(Config{})
+	encoderConfigType = reflect.TypeOf(EncoderConfig{})
+)
+
+// fieldName returns the name used for a struct field in merge paths, which is the
+// same as its JSON name.  Fields that are not marshaled to JSON, such as
+// Config.Mapping, have no name and cannot be referred to by a path.
+func fieldName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+
+	case "":
+		return f.Name
+
+	default:
+		return name
+	}
+}
+
+// structType returns the struct type t refers to, either directly or through a
+// pointer.  If t is neither, this function returns nil.
+func structType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() == reflect.Struct {
+		return t
+	}
+
+	return nil
+}
+
+// fieldPaths adds the path of every named field of the given struct type, including
+// fields of nested structs, to paths.
+func fieldPaths(prefix string, t reflect.Type, paths map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := fieldName(f)
+		if !f.IsExported() || len(name) == 0 {
+			continue
+		}
+
+		path := joinPath(prefix, name)
+		paths[path] = true
+		if st := structType(f.Type); st != nil {
+			fieldPaths(path, st, paths)
+		}
+	}
+}
+
+// merger performs a deep merge of one value onto another.  The explicit paths are
+// the fields that are considered set in the overlay even when they hold a zero value.
+type merger struct {
+	explicit map[string]bool
+}
+
+// newMerger creates a merger for values of the given struct type.  Each explicit path
+// must name a field of that type.
+func newMerger(t reflect.Type, explicit []string) (m merger, err error) {
+	if len(explicit) == 0 {
+		return
+	}
+
+	known := make(map[string]bool)
+	fieldPaths("", t, known)
+
+	var errs []error
+	m.explicit = make(map[string]bool, len(explicit))
+	for _, path := range explicit {
+		if known[path] {
+			m.explicit[path] = true
+		} else {
+			errs = append(errs, &ValidationError{
+				Path: path,
+				Err:  errors.New("unknown field"),
+			})
+		}
+	}
+
+	err = errors.Join(errs...)
+	return
+}
+
+// explicitBelow tests if any explicit path refers to a field nested beneath path.
+func (m merger) explicitBelow(path string) bool {
+	prefix := path + "."
+	for p := range m.explicit {
+		if strings.HasPrefix(p, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// copyValue returns a copy of v that does not share any slice, map, or struct pointer
+// with v.  Only the top level is copied.
+func copyValue(v reflect.Value) reflect.Value {
+	switch {
+	case v.Kind() == reflect.Slice && !v.IsNil():
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(c, v)
+		return c
+
+	case v.Kind() == reflect.Map && !v.IsNil():
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			c.SetMapIndex(iter.Key(), iter.Value())
+		}
+
+		return c
+
+	case v.Kind() == reflect.Pointer && !v.IsNil() && v.Elem().Kind() == reflect.Struct:
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(v.Elem())
+		return c
+
+	default:
+		return v
+	}
+}
+
+// mergeValue merges over into base, which must be settable.  The path is the location
+// of the value, and is empty for a value that cannot be referred to by path.
+func (m merger) mergeValue(path string, base, over reflect.Value) {
+	if len(path) > 0 && m.explicit[path] {
+		base.Set(copyValue(over))
+		return
+	}
+
+	switch over.Kind() {
+	case reflect.Struct:
+		m.mergeStruct(path, base, over)
+
+	case reflect.Pointer:
+		// pointers without a path, such as Config.SamplingCounter, refer to shared
+		// objects rather than configuration, so they are never merged
+		if over.Type().Elem().Kind() != reflect.Struct || len(path) == 0 {
+			if !over.IsNil() {
+				base.Set(over)
+			}
+
+			return
+		}
+
+		if over.IsNil() {
+			if len(path) == 0 || !m.explicitBelow(path) {
+				return
+			}
+
+			// explicit zero values for fields within a nil struct
+			over = reflect.New(over.Type().Elem())
+		}
+
+		merged := reflect.New(over.Type().Elem())
+		if !base.IsNil() {
+			merged.Elem().Set(base.Elem())
+		}
+
+		m.mergeStruct(path, merged.Elem(), over.Elem())
+		base.Set(merged)
+
+	case reflect.Map:
+		if over.Len() == 0 {
+			return
+		}
+
+		merged := copyValue(base)
+		if merged.IsNil() {
+			merged = reflect.MakeMapWithSize(over.Type(), over.Len())
+		}
+
+		for iter := over.MapRange(); iter.Next(); {
+			merged.SetMapIndex(iter.Key(), iter.Value())
+		}
+
+		base.Set(merged)
+
+	case reflect.Slice:
+		if over.Len() > 0 {
+			base.Set(copyValue(over))
+		}
+
+	case reflect.Func, reflect.Interface:
+		if !over.IsNil() {
+			base.Set(over)
+		}
+
+	default:
+		if !over.IsZero() {
+			base.Set(over)
+		}
+	}
+}
+
+func (m merger) mergeStruct(path string, base, over reflect.Value) {
+	t := over.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		var fieldPath string
+		if name := fieldName(f); len(name) > 0 {
+			fieldPath = joinPath(path, name)
+		}
+
+		m.mergeValue(fieldPath, base.Field(i), over.Field(i))
+	}
+
+	if t == encoderConfigType {
+		m.mergeTimeZone(path, base.Addr().Interface().(*EncoderConfig), over.Interface().(EncoderConfig))
+	}
+}
+
+// mergeTimeZone treats EncoderConfig.TimeZone and EncoderConfig.UTC as a single setting,
+// since they cannot be combined.  Setting one in the overlay clears the other in the base,
+// unless the other is also explicitly set.
+func (m merger) mergeTimeZone(path string, base *EncoderConfig, over EncoderConfig) {
+	if len(over.TimeZone) > 0 && !m.explicit[joinPath(path, "utc")] {
+		base.UTC = over.UTC
+	}
+
+	if over.UTC && !m.explicit[joinPath(path, "timeZone")] {
+		base.TimeZone = over.TimeZone
+	}
+}
+
+// Merge returns a copy of this Config with the given overlay merged onto it.  Neither
+// this Config nor the overlay is modified.  Presets are not applied.
+//
+// A field of the overlay is set if it has a nonzero value, or if its path is one of the
+// explicit paths or is listed in the overlay's Explicit field.  Paths use the same JSON names as Validate, e.g. "development",
+// "rotation.maxage", or "encoderConfig.utc".  Listing a path is how an overlay sets a field
+// to its zero value, such as turning off Development or clearing OutputPaths.  An explicit
+// path that does not name a field results in an error.
+//
+// Fields that are set in the overlay are merged as follows:
+//
+//   - Strings, numbers, and booleans replace the value in this Config.
+//   - Slices, such as OutputPaths and Cores, replace the value in this Config.  They are never appended.
+//   - Maps, such as Levels and InitialFields, are merged key by key with the overlay's keys taking
+//     precedence.  If the map's path is explicit, the overlay's map replaces this Config's map entirely.
+//   - Structs and struct pointers, such as EncoderConfig, Rotation, and Sampling, are merged field by
+//     field using these same rules.  If the field's path is explicit, the overlay's value replaces
+//     this Config's value entirely, so an explicit nil Rotation turns off rotation.
+//   - Mapping, which has no path, replaces this Config's Mapping if it is non-nil.
+//
+// As with Validate, EncoderConfig.TimeZone and EncoderConfig.UTC cannot be combined.  An overlay that
+// sets one of them clears the other unless both are set.
+//
+// The Explicit field of the returned Config lists the explicit fields of both this Config and the
+// overlay, so that merging a series of overlays onto a preset overrides it with all of them.
+func (c Config) Merge(overlay Config, explicit ...string) (Config, error) {
+	m, err := newMerger(configType, addPaths(overlay.Explicit, explicit))
+	if err != nil {
+		return c, err
+	}
+
+	base := c.Explicit
+	m.mergeValue("", reflect.ValueOf(&c).Elem(), reflect.ValueOf(overlay))
+	c.Explicit = addPaths(base, overlay.Explicit)
+	return c, nil
+}
+
+// Merge returns a copy of this EncoderConfig with the given overlay merged onto it, using
+// the same rules as Config.Merge.  The explicit paths are relative to this EncoderConfig,
+// e.g. "utc" or "callerKey".
+func (ec EncoderConfig) Merge(overlay EncoderConfig, explicit ...string) (EncoderConfig, error) {
+	m, err := newMerger(encoderConfigType, explicit)
+	if err != nil {
+		return ec, err
+	}
+
+	m.mergeValue("", reflect.ValueOf(&ec).Elem(), reflect.ValueOf(overlay))
+	return ec, nil
+}
+
+// fieldByJSONName finds the exported field of a struct type with the given JSON name.
+// As with json.Unmarshal, the name is matched case-insensitively.
+func fieldByJSONName(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if name := fieldName(f); f.IsExported() && len(name) > 0 && strings.EqualFold(name, key) {
+			return f, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// asObject converts a decoded object into a map keyed by strings.  YAML libraries may
+// decode objects into maps with keys of other types.  If v is not a map, this function
+// returns false.
+func asObject(v interface{}) (map[string]interface{}, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Map {
+		return nil, false
+	}
+
+	object := make(map[string]interface{}, rv.Len())
+	for i := rv.MapRange(); i.Next(); {
+		object[fmt.Sprint(i.Key().Interface())] = i.Value().Interface()
+	}
+
+	return object, true
+}
+
+// objectPaths returns the explicit paths for a decoded object being merged onto a value of the
+// given struct type.  Every key in the object is considered set, except that nested objects
+// for structs are descended into and nonempty objects for maps are merged key by key.  Keys
+// that do not correspond to a field are ignored, as they are by json.Unmarshal.
+func objectPaths(prefix string, object map[string]interface{}, t reflect.Type) (paths []string) {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		f, ok := fieldByJSONName(t, key)
+		if !ok {
+			continue
+		}
+
+		var (
+			path             = joinPath(prefix, fieldName(f))
+			nested, isObject = asObject(object[key])
+		)
+
+		switch st := structType(f.Type); {
+		case st != nil && isObject:
+			paths = append(paths, objectPaths(path, nested, st)...)
+
+		case f.Type.Kind() == reflect.Map && isObject && len(nested) > 0:
+			// merged key by key
+
+		default:
+			paths = append(paths, path)
+		}
+	}
+
+	return
+}
+
+// addPaths returns the sorted union of two lists of explicit paths.
+func addPaths(paths, more []string) []string {
+	if len(more) == 0 {
+		return paths
+	}
+
+	union := append(append([]string{}, paths...), more...)
+	sort.Strings(union)
+	return slices.Compact(union)
+}
+
+// UnmarshalJSON decodes a Config as usual, adding the path of every field that appears in
+// the JSON to Explicit.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	// the conversion drops the methods of Config, so that this does not recurse
+	type config Config
+	if err := json.Unmarshal(data, (*config)(c)); err != nil {
+		return err
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+
+	c.Explicit = addPaths(c.Explicit, objectPaths("", object, configType))
+	return nil
+}
+
+// UnmarshalYAML decodes a Config in the same way as UnmarshalJSON.  This method has
+// the signature of the unmarshaler interface that YAML libraries support.
+func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type config Config
+	if err := unmarshal((*config)(c)); err != nil {
+		return err
+	}
+
+	var object map[string]interface{}
+	if err := unmarshal(&object); err != nil {
+		return err
+	}
+
+	c.Explicit = addPaths(c.Explicit, objectPaths("", object, configType))
+	return nil
+}
+
+// MergeJSON unmarshals a JSON object as an overlay and merges it onto a copy of this Config
+// using Merge.  Every field that appears in the JSON is considered set, even when its value is
+// a zero value, while fields that do not appear leave this Config's values alone.  A map that
+// appears in the JSON is merged key by key unless it is null or an empty object, which clears it.
+//
+// This is useful for layering configuration files, e.g. a base file followed by an
+// environment-specific file.
+func (c Config) MergeJSON(data []byte) (Config, error) {
+	var overlay Config
+	if err := json.Unmarshal(data, &overlay); err != nil {
+		return c, err
+	}
+
+	return c.Merge(overlay)
+}
@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testBaseConfig is the base configuration used by the merge tests.
+func testBaseConfig() Config {
+	return Config{
+		Level:       "info",
+		Development: true,
+		Levels: map[string]string{
+			"fx":   "warn",
+			"http": "info",
+		},
+		Encoding: "json",
+		EncoderConfig: EncoderConfig{
+			MessageKey: "msg",
+			CallerKey:  "caller",
+			UTC:        true,
+		},
+		OutputPaths: []string{"/var/log/base.log", Stdout},
+		InitialFields: map[string]interface{}{
+			"service": "base",
+		},
+		Rotation: &Rotation{
+			MaxSize: 10,
+			MaxAge:  3,
+		},
+		Sampling: &zap.SamplingConfig{
+			Initial:    100,
+			Thereafter: 100,
+		},
+	}
+}
+
+func testMergeNonZero(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		base    = testBaseConfig()
+	)
+
+	merged, err := base.Merge(Config{
+		Level: "debug",
+		Levels: map[string]string{
+			"http": "debug",
+		},
+		OutputPaths: []string{Stderr},
+		EncoderConfig: EncoderConfig{
+			TimeZone: "America/New_York",
+		},
+		Rotation: &Rotation{
+			MaxBackups: 5,
+		},
+		Sampling: &zap.SamplingConfig{
+			Thereafter: 10,
+		},
+	})
+
+	require.NoError(err)
+	assert.Equal("debug", merged.Level)
+	assert.True(merged.Development)
+	assert.Equal(map[string]string{"fx": "warn", "http": "debug"}, merged.Levels)
+	assert.Equal("json", merged.Encoding)
+	assert.Equal([]string{Stderr}, merged.OutputPaths)
+	assert.Equal(map[string]interface{}{"service": "base"}, merged.InitialFields)
+	assert.Equal(&Rotation{MaxSize: 10, MaxAge: 3, MaxBackups: 5}, merged.Rotation)
+	assert.Equal(&zap.SamplingConfig{Initial: 100, Thereafter: 10}, merged.Sampling)
+	assert.Equal(
+		EncoderConfig{
+			MessageKey: "msg",
+			CallerKey:  "caller",
+			TimeZone:   "America/New_York",
+		},
+		merged.EncoderConfig,
+	)
+
+	assert.NoError(merged.Validate())
+
+	// neither the base nor its nested values are modified
+	assert.Equal(testBaseConfig(), base)
+}
+
+func testMergeExplicit(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	merged, err := testBaseConfig().Merge(
+		Config{},
+		"development",
+		"levels",
+		"outputPaths",
+		"encoderConfig.callerKey",
+		"rotation.maxage",
+		"samplingConfig",
+	)
+
+	require.NoError(err)
+	assert.Equal("info", merged.Level)
+	assert.False(merged.Development)
+	assert.Empty(merged.Levels)
+	assert.Empty(merged.OutputPaths)
+	assert.Empty(merged.EncoderConfig.CallerKey)
+	assert.Equal("msg", merged.EncoderConfig.MessageKey)
+	assert.Equal(&Rotation{MaxSize: 10}, merged.Rotation)
+	assert.Nil(merged.Sampling)
+
+	// explicit fields beneath a nil struct pointer
+	merged, err = Config{}.Merge(Config{}, "rotation.compress")
+	require.NoError(err)
+	assert.Equal(&Rotation{}, merged.Rotation)
+}
+
+func testMergeUnknownPath(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		base   = testBaseConfig()
+	)
+
+	merged, err := base.Merge(Config{Level: "debug"}, "development", "nosuchfield", "rotation.nosuchfield")
+	assert.Equal([]string{"nosuchfield", "rotation.nosuchfield"}, validationPaths(t, err))
+	assert.Equal(base, merged)
+}
+
+func testMergeMapping(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		mapping = func(string) string { return "mapped" }
+	)
+
+	merged, err := Config{}.Merge(Config{Mapping: mapping})
+	require.NoError(err)
+	require.NotNil(merged.Mapping)
+	assert.Equal("mapped", merged.Mapping("x"))
+}
+
+func testMergeJSON(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	merged, err := testBaseConfig().MergeJSON([]byte(`{
+		"level": "warn",
+		"development": false,
+		"levels": {"grpc": "error"},
+		"initialFields": {},
+		"encoderConfig": {"callerKey": "", "timeZone": "Asia/Tokyo"},
+		"rotation": {"maxAge": 0},
+		"samplingConfig": null,
+		"unknownField": "ignored"
+	}`))
+
+	require.NoError(err)
+	assert.Equal("warn", merged.Level)
+	assert.False(merged.Development)
+	assert.Equal(map[string]string{"fx": "warn", "http": "info", "grpc": "error"}, merged.Levels)
+	assert.Empty(merged.InitialFields)
+	assert.Equal("json", merged.Encoding)
+	assert.Equal([]string{"/var/log/base.log", Stdout}, merged.OutputPaths)
+	assert.Equal(
+		EncoderConfig{
+			MessageKey: "msg",
+			TimeZone:   "Asia/Tokyo",
+		},
+		merged.EncoderConfig,
+	)
+
+	assert.Equal(&Rotation{MaxSize: 10}, merged.Rotation)
+	assert.Nil(merged.Sampling)
+
+	_, err = testBaseConfig().MergeJSON([]byte("this is not JSON"))
+	assert.Error(err)
+}
+
+func testEncoderConfigMerge(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		base    = EncoderConfig{
+			MessageKey: "msg",
+			TimeZone:   "America/New_York",
+		}
+	)
+
+	merged, err := base.Merge(EncoderConfig{LevelKey: "lvl", UTC: true}, "messageKey")
+	require.NoError(err)
+	assert.Equal(EncoderConfig{LevelKey: "lvl", UTC: true}, merged)
+
+	_, err = base.Merge(EncoderConfig{}, "encoderConfig.utc")
+	assert.Equal([]string{"encoderConfig.utc"}, validationPaths(t, err))
+}
+
+func TestMerge(t *testing.T) {
+	t.Run("NonZero", testMergeNonZero)
+	t.Run("Explicit", testMergeExplicit)
+	t.Run("UnknownPath", testMergeUnknownPath)
+	t.Run("Mapping", testMergeMapping)
+	t.Run("JSON", testMergeJSON)
+	t.Run("EncoderConfig", testEncoderConfigMerge)
+}
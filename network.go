@@ -0,0 +1,416 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// TCPScheme is the URL scheme for sending log entries over TCP, e.g. tcp://localhost:5170
+	TCPScheme = "tcp"
+
+	// UDPScheme is the URL scheme for sending log entries over UDP, e.g. udp://localhost:5170.
+	// Each write is sent as a single datagram, without framing.
+	UDPScheme = "udp"
+
+	// FramingParameter is the network URL parameter that selects how entries are delimited
+	// on a TCP connection:  either NewlineFraming or OctetCountingFraming.
+	FramingParameter = "framing"
+
+	// BacklogParameter is the network URL parameter for the maximum number of writes held in
+	// memory while disconnected.  When the backlog is full, the oldest write is discarded.
+	BacklogParameter = "backlog"
+
+	// WriteTimeoutParameter is the network and syslog URL parameter for the time allowed for
+	// each write, and for each connection attempt.  The value is parsed with time.ParseDuration.
+	WriteTimeoutParameter = "writeTimeout"
+
+	// MinBackoffParameter is the network and syslog URL parameter for the delay after the first
+	// failed connection attempt.  The delay doubles after each further failure.
+	MinBackoffParameter = "minBackoff"
+
+	// MaxBackoffParameter is the network and syslog URL parameter for the longest delay between
+	// connection attempts.
+	MaxBackoffParameter = "maxBackoff"
+
+	// NewlineFraming is the FramingParameter value that terminates each entry with a
+	// newline.  This is the default.
+	NewlineFraming = "newline"
+
+	// OctetCountingFraming is the FramingParameter value that prefixes each entry with its
+	// length in bytes and a space, as described in RFC 6587.
+	OctetCountingFraming = "octet-counting"
+
+	// DefaultBacklog is the backlog used when a network URL has no BacklogParameter.
+	DefaultBacklog = 1000
+
+	// DefaultWriteTimeout is the timeout used when a network URL has no WriteTimeoutParameter.
+	DefaultWriteTimeout = 5 * time.Second
+
+	// DefaultMinBackoff is the delay used when a network URL has no MinBackoffParameter.
+	DefaultMinBackoff = 100 * time.Millisecond
+
+	// DefaultMaxBackoff is the delay used when a network URL has no MaxBackoffParameter.
+	DefaultMaxBackoff = 30 * time.Second
+)
+
+// errNetworkClosed is returned for writes to a closed Network sink.
+var errNetworkClosed = errors.New("network sink is closed")
+
+func init() {
+	registerSink(TCPScheme, NewNetworkSink)
+	registerSink(UDPScheme, NewNetworkSink)
+}
+
+// isNetworkScheme tests if a URL scheme refers to a network address or socket rather than a file.
+func isNetworkScheme(scheme string) bool {
+	switch strings.ToLower(scheme) {
+	case TCPScheme, UDPScheme, HTTPScheme, HTTPSScheme:
+		return true
+
+	default:
+		return isSyslogScheme(scheme)
+	}
+}
+
+// Network is a zap.Sink that sends log entries to a TCP or UDP address.  Connecting happens
+// in the background, starting when the sink is created.  While disconnected, writes are held
+// in a bounded backlog, and connection attempts are retried with exponential backoff.  Once
+// connected, the backlog is sent before any new writes.
+//
+// Writes never wait for a connection to be made or for the backlog to be sent, and each write
+// to a connection is bounded by the write timeout.  A write that fails is added to the backlog,
+// and the connection is remade.  A write that was only partly sent is discarded instead.
+//
+// A Network is safe for concurrent writes.  No additional synchronization is required.
+type Network struct {
+	network      string
+	address      string
+	framing      string
+	backlogSize  int
+	writeTimeout time.Duration
+	minBackoff   time.Duration
+	maxBackoff   time.Duration
+
+	lock         sync.Mutex
+	conn         net.Conn
+	dialed       net.Conn
+	backlog      [][]byte
+	dropped      uint64
+	reconnecting bool
+	closed       bool
+	done         chan struct{}
+}
+
+var _ zap.Sink = (*Network)(nil)
+
+// newNetwork parses a network URL without connecting.
+func newNetwork(u *url.URL) (*Network, error) {
+	n := &Network{
+		network:      strings.ToLower(u.Scheme),
+		address:      u.Host,
+		framing:      NewlineFraming,
+		backlogSize:  DefaultBacklog,
+		writeTimeout: DefaultWriteTimeout,
+		minBackoff:   DefaultMinBackoff,
+		maxBackoff:   DefaultMaxBackoff,
+		done:         make(chan struct{}),
+	}
+
+	if n.network != TCPScheme && n.network != UDPScheme {
+		return nil, fmt.Errorf("Invalid network scheme [%s]", u.Scheme) // nolint:staticcheck
+	}
+
+	if _, _, err := net.SplitHostPort(n.address); err != nil {
+		return nil, fmt.Errorf("Invalid network address [%s]: %w", n.address, err) // nolint:staticcheck
+	}
+
+	values, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := strings.ToLower(values.Get(FramingParameter)); v {
+	case "", NewlineFraming:
+	case OctetCountingFraming:
+		n.framing = v
+	default:
+		return nil, fmt.Errorf("Invalid network framing [%s]", v) // nolint:staticcheck
+	}
+
+	if v := values.Get(BacklogParameter); len(v) > 0 {
+		n.backlogSize, err = strconv.Atoi(v)
+		if err != nil || n.backlogSize < 0 {
+			return nil, fmt.Errorf("Invalid network backlog [%s]", v) // nolint:staticcheck
+		}
+	}
+
+	for _, d := range []struct {
+		name  string
+		value *time.Duration
+	}{
+		{WriteTimeoutParameter, &n.writeTimeout},
+		{MinBackoffParameter, &n.minBackoff},
+		{MaxBackoffParameter, &n.maxBackoff},
+	} {
+		if v := values.Get(d.name); len(v) > 0 {
+			*d.value, err = time.ParseDuration(v)
+			if err != nil || *d.value <= 0 {
+				return nil, fmt.Errorf("Invalid network %s [%s]", d.name, v) // nolint:staticcheck
+			}
+		}
+	}
+
+	if n.maxBackoff < n.minBackoff {
+		n.maxBackoff = n.minBackoff
+	}
+
+	return n, nil
+}
+
+// NewNetworkSink creates a zap.Sink for a tcp or udp URL and starts connecting.  The URL's
+// query may contain FramingParameter, BacklogParameter, WriteTimeoutParameter,
+// MinBackoffParameter, and MaxBackoffParameter.  This package registers this factory
+// with zap.RegisterSink for TCPScheme and UDPScheme.
+func NewNetworkSink(u *url.URL) (zap.Sink, error) {
+	n, err := newNetwork(u)
+	if err != nil {
+		return nil, err
+	}
+
+	n.reconnecting = true
+	go n.reconnect()
+	return n, nil
+}
+
+// frame copies p and applies this sink's framing.
+func (n *Network) frame(p []byte) []byte {
+	switch {
+	case n.network == UDPScheme:
+		return append([]byte{}, p...)
+
+	case n.framing == OctetCountingFraming:
+		p = bytes.TrimRight(p, "\n")
+		return append([]byte(strconv.Itoa(len(p))+" "), p...)
+
+	case len(p) > 0 && p[len(p)-1] == '\n':
+		return append([]byte{}, p...)
+
+	default:
+		return append(append([]byte{}, p...), '\n')
+	}
+}
+
+// enqueue adds a message to the backlog, discarding the oldest message if the backlog
+// is full.  The lock must be held.
+func (n *Network) enqueue(msg []byte) {
+	if n.backlogSize == 0 {
+		n.dropped++
+		return
+	}
+
+	if len(n.backlog) >= n.backlogSize {
+		n.backlog = n.backlog[1:]
+		n.dropped++
+	}
+
+	n.backlog = append(n.backlog, msg)
+}
+
+// write writes a message to a connection, bounded by the write timeout.  The number of
+// bytes written is returned along with any error, so that a partial write can be detected.
+func (n *Network) write(conn net.Conn, msg []byte) (written int, err error) {
+	if n.writeTimeout > 0 {
+		err = conn.SetWriteDeadline(time.Now().Add(n.writeTimeout))
+	}
+
+	if err == nil {
+		written, err = conn.Write(msg)
+	}
+
+	return
+}
+
+// requeue puts messages that could not be sent back at the front of the backlog, discarding
+// the oldest messages if the backlog overflows.  The lock must be held.
+func (n *Network) requeue(msgs [][]byte) {
+	n.backlog = append(msgs[:len(msgs):len(msgs)], n.backlog...)
+	if excess := len(n.backlog) - n.backlogSize; excess > 0 {
+		n.backlog = n.backlog[excess:]
+		n.dropped += uint64(excess)
+	}
+}
+
+// flush sends the backlog over a newly dialed connection without holding the lock, so that
+// writes made meanwhile are added to the backlog rather than waiting.  Once the backlog is
+// empty, the connection becomes current.  If a send fails, the connection is closed and the
+// unsent messages are put back in the backlog.  A message that was only partly sent is
+// discarded, since its remainder cannot be framed on another connection.
+func (n *Network) flush(conn net.Conn) error {
+	for {
+		n.lock.Lock()
+		if n.closed {
+			n.lock.Unlock()
+			conn.Close()
+			return errNetworkClosed
+		}
+
+		backlog := n.backlog
+		if len(backlog) == 0 {
+			n.conn = conn
+			n.dialed = nil
+			n.reconnecting = false
+			n.lock.Unlock()
+			return nil
+		}
+
+		n.backlog = nil
+		n.dialed = conn
+		n.lock.Unlock()
+
+		for i, msg := range backlog {
+			written, err := n.write(conn, msg)
+			if err == nil {
+				continue
+			}
+
+			conn.Close()
+
+			n.lock.Lock()
+			n.dialed = nil
+			if written > 0 {
+				n.dropped++
+				i++
+			}
+
+			if !n.closed {
+				n.requeue(backlog[i:])
+			}
+
+			n.lock.Unlock()
+			return err
+		}
+	}
+}
+
+// disconnected starts reconnecting, if that is not already happening.  The lock must be held.
+func (n *Network) disconnected() {
+	if !n.reconnecting && !n.closed {
+		n.reconnecting = true
+		go n.reconnect()
+	}
+}
+
+// reconnect dials until a connection is made and the backlog is sent, or until this sink is closed.
+func (n *Network) reconnect() {
+	backoff := n.minBackoff
+	for {
+		conn, err := net.DialTimeout(n.network, n.address, n.writeTimeout)
+		if err == nil {
+			err = n.flush(conn)
+		}
+
+		if err == nil || errors.Is(err, errNetworkClosed) {
+			return
+		}
+
+		select {
+		case <-n.done:
+			return
+
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > n.maxBackoff {
+			backoff = n.maxBackoff
+		}
+	}
+}
+
+// Write sends p, or adds it to the backlog if there is no connection.  If sending fails, the
+// connection is closed and remade.  A message that failed before any of it was sent is added
+// to the backlog, while a message that was only partly sent is discarded.  An error is only
+// returned if this sink has been closed.
+func (n *Network) Write(p []byte) (int, error) {
+	msg := n.frame(p)
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	switch {
+	case n.closed:
+		return 0, errNetworkClosed
+
+	case n.conn == nil:
+		n.enqueue(msg)
+
+	default:
+		written, err := n.write(n.conn, msg)
+		if err == nil {
+			break
+		}
+
+		n.conn.Close()
+		n.conn = nil
+		if written > 0 {
+			n.dropped++
+		} else {
+			n.enqueue(msg)
+		}
+
+		n.disconnected()
+	}
+
+	return len(p), nil
+}
+
+// Sync does nothing.  Writes are sent as they are made, and the backlog is sent in the
+// background as soon as a connection is made.
+func (n *Network) Sync() error {
+	return nil
+}
+
+// Dropped returns the number of writes discarded because the backlog was full or because
+// they were only partly sent.
+func (n *Network) Dropped() uint64 {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.dropped
+}
+
+// Close stops reconnecting and closes the connection, if any.  Any backlog is discarded.
+func (n *Network) Close() (err error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.closed {
+		return nil
+	}
+
+	n.closed = true
+	n.backlog = nil
+	close(n.done)
+	if n.dialed != nil {
+		n.dialed.Close()
+	}
+
+	if n.conn != nil {
+		err = n.conn.Close()
+		n.conn = nil
+	}
+
+	return
+}
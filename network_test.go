@@ -0,0 +1,313 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testNetworkSink creates a Network sink for a URL, closing it when the test ends.
+func testNetworkSink(t *testing.T, rawURL string) *Network {
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err)
+
+	sink, err := NewNetworkSink(u)
+	require.NoError(t, err)
+	t.Cleanup(func() { sink.Close() })
+	return sink.(*Network)
+}
+
+// testAccept accepts a single connection, closing it when the test ends.
+func testAccept(t *testing.T, listener net.Listener) *bufio.Reader {
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	return bufio.NewReader(conn)
+}
+
+func testNetworkInvalid(t *testing.T) {
+	for _, path := range []string{
+		"tcp://localhost",
+		"tcp://localhost:5170?framing=nosuch",
+		"tcp://localhost:5170?backlog=-1",
+		"tcp://localhost:5170?backlog=many",
+		"udp://localhost:5170?writeTimeout=soon",
+		"udp://localhost:5170?minBackoff=0s",
+		"udp://localhost:5170?maxBackoff=-1s",
+		"lumberjack:///var/log/test.log",
+	} {
+		t.Run(path, func(t *testing.T) {
+			u, err := url.Parse(path)
+			require.NoError(t, err)
+
+			s, err := NewNetworkSink(u)
+			assert.Nil(t, s)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func testNetworkNewline(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer listener.Close()
+
+	l, err := Config{
+		OutputPaths: []string{fmt.Sprintf("tcp://%s", listener.Addr())},
+		Permissions: "0600",
+	}.Build()
+
+	require.NoError(err)
+	l.Info("first")
+	l.Info("second")
+
+	r := testAccept(t, listener)
+	for _, expected := range []string{"first", "second"} {
+		line, err := r.ReadString('\n')
+		require.NoError(err)
+		assert.Contains(line, `"msg":"`+expected+`"`)
+	}
+}
+
+func testNetworkOctetCounting(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer listener.Close()
+
+	sink := testNetworkSink(t, fmt.Sprintf("tcp://%s?framing=octet-counting", listener.Addr()))
+	_, err = sink.Write([]byte("hello world\n"))
+	require.NoError(err)
+
+	r := testAccept(t, listener)
+	frame := make([]byte, len("11 hello world"))
+	_, err = io.ReadFull(r, frame)
+	require.NoError(err)
+	assert.Equal("11 hello world", string(frame))
+}
+
+func testNetworkBacklog(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	// reserve a port with nothing listening on it
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	sink := testNetworkSink(t, fmt.Sprintf("tcp://%s?backlog=2&minBackoff=10ms&maxBackoff=20ms", address))
+	for i := 0; i < 5; i++ {
+		n, err := sink.Write([]byte(fmt.Sprintf("message %d\n", i)))
+		assert.Equal(len("message 0\n"), n)
+		assert.NoError(err)
+	}
+
+	assert.Equal(uint64(3), sink.Dropped())
+
+	listener, err = net.Listen("tcp", address)
+	require.NoError(err)
+	defer listener.Close()
+
+	// once reconnected, the backlog is sent before new writes
+	r := testAccept(t, listener)
+	assert.Eventually(
+		func() bool {
+			sink.lock.Lock()
+			defer sink.lock.Unlock()
+			return sink.conn != nil
+		},
+		time.Second,
+		10*time.Millisecond,
+	)
+
+	_, err = sink.Write([]byte("message 5\n"))
+	require.NoError(err)
+
+	var lines []string
+	for i := 0; i < 3; i++ {
+		line, err := r.ReadString('\n')
+		require.NoError(err)
+		lines = append(lines, strings.TrimSpace(line))
+	}
+
+	assert.Equal([]string{"message 3", "message 4", "message 5"}, lines)
+}
+
+func testNetworkFlushUnlocked(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	// reserve a port with nothing listening on it
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	// a backlog large enough that sending it blocks until the collector reads
+	big := strings.Repeat("x", 1<<20) + "\n"
+	sink := testNetworkSink(t, fmt.Sprintf("tcp://%s?writeTimeout=10s&minBackoff=10ms&maxBackoff=20ms", address))
+	for i := 0; i < 32; i++ {
+		_, err := sink.Write([]byte(big))
+		require.NoError(err)
+	}
+
+	listener, err = net.Listen("tcp", address)
+	require.NoError(err)
+	defer listener.Close()
+
+	r := testAccept(t, listener)
+	assert.Eventually(
+		func() bool {
+			sink.lock.Lock()
+			defer sink.lock.Unlock()
+			return sink.dialed != nil
+		},
+		time.Second,
+		10*time.Millisecond,
+	)
+
+	// writes made while the backlog is being sent go to the backlog without waiting
+	start := time.Now()
+	_, err = sink.Write([]byte("last\n"))
+	require.NoError(err)
+	assert.Less(time.Since(start), time.Second)
+
+	for i := 0; i < 32; i++ {
+		line, err := r.ReadString('\n')
+		require.NoError(err)
+		require.Equal(big, line)
+	}
+
+	line, err := r.ReadString('\n')
+	require.NoError(err)
+	assert.Equal("last\n", line)
+	assert.Zero(sink.Dropped())
+}
+
+func testNetworkPartialWrite(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer listener.Close()
+
+	sink := testNetworkSink(t, fmt.Sprintf("tcp://%s?writeTimeout=200ms&minBackoff=10ms&maxBackoff=20ms", listener.Addr()))
+
+	// the first collector never reads, so a large enough write only partly succeeds
+	testAccept(t, listener)
+	assert.Eventually(
+		func() bool {
+			sink.lock.Lock()
+			defer sink.lock.Unlock()
+			return sink.conn != nil
+		},
+		time.Second,
+		10*time.Millisecond,
+	)
+
+	_, err = sink.Write([]byte(strings.Repeat("x", 32<<20)))
+	require.NoError(err)
+	assert.Equal(uint64(1), sink.Dropped())
+
+	// the partly sent message is not resent on the new connection
+	_, err = sink.Write([]byte("after\n"))
+	require.NoError(err)
+
+	r := testAccept(t, listener)
+	line, err := r.ReadString('\n')
+	require.NoError(err)
+	assert.Equal("after\n", line)
+}
+
+func testNetworkUDP(t *testing.T) {
+	require := require.New(t)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(err)
+	defer conn.Close()
+
+	sink, closer, err := zap.Open(fmt.Sprintf("udp://%s", conn.LocalAddr()))
+	require.NoError(err)
+	defer closer()
+
+	// the first write may be held until the background connection is made
+	go func() {
+		for i := 0; i < 10; i++ {
+			sink.Write([]byte("datagram\n"))
+			sink.Sync()
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	require.NoError(conn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+	buf := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(err)
+	assert.Equal(t, "datagram\n", string(buf[:n]))
+}
+
+func testNetworkClosed(t *testing.T) {
+	sink := testNetworkSink(t, "tcp://127.0.0.1:1?minBackoff=1h")
+	require.NoError(t, sink.Close())
+	require.NoError(t, sink.Close())
+
+	n, err := sink.Write([]byte("closed"))
+	assert.Zero(t, n)
+	assert.Error(t, err)
+}
+
+func testNetworkValidate(t *testing.T) {
+	assert.Equal(
+		t,
+		[]string{"outputPaths[1]", "outputPaths[2]"},
+		validationPaths(t, Config{
+			OutputPaths: []string{
+				"tcp://localhost:5170?framing=octet-counting",
+				"tcp://localhost:5170?framing=nosuch",
+				"udp://localhost",
+			},
+		}.Validate()),
+	)
+}
+
+func TestNetwork(t *testing.T) {
+	t.Run("Invalid", testNetworkInvalid)
+	t.Run("Newline", testNetworkNewline)
+	t.Run("OctetCounting", testNetworkOctetCounting)
+	t.Run("Backlog", testNetworkBacklog)
+	t.Run("FlushUnlocked", testNetworkFlushUnlocked)
+	t.Run("PartialWrite", testNetworkPartialWrite)
+	t.Run("UDP", testNetworkUDP)
+	t.Run("Closed", testNetworkClosed)
+	t.Run("Validate", testNetworkValidate)
+}
@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"sort"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PresetProduction is the name of the preset returned by NewProductionConfig.
+	PresetProduction = "production"
+
+	// PresetDevelopment is the name of the preset returned by NewDevelopmentConfig.
+	PresetDevelopment = "development"
+
+	// PresetTest is the name of the preset returned by NewTestConfig.
+	PresetTest = "test"
+
+	// PresetContainer is the name of the preset returned by NewContainerConfig.
+	PresetContainer = "container"
+)
+
+// NewProductionConfig returns the baseline for production services:  info level
+// JSON output to stderr, with callers, ISO8601 timestamps, stacktraces for errors,
+// and the same sampling as zap.NewProductionConfig.
+func NewProductionConfig() Config {
+	return Config{
+		Level:    "info",
+		Encoding: "json",
+		Sampling: &zap.SamplingConfig{
+			Initial:    100,
+			Thereafter: 100,
+		},
+		EncoderConfig: EncoderConfig{
+			CallerKey:     "caller",
+			StacktraceKey: "stacktrace",
+			EncodeTime:    "ISO8601",
+		},
+		OutputPaths:      []string{Stderr},
+		ErrorOutputPaths: []string{Stderr},
+	}
+}
+
+// NewDevelopmentConfig returns the baseline for local development:  debug level
+// console output to stdout, in development mode, with colored levels, callers,
+// ISO8601 timestamps, and stacktraces for warnings.  There is no sampling.
+func NewDevelopmentConfig() Config {
+	return Config{
+		Level:       "debug",
+		Development: true,
+		Encoding:    "console",
+		EncoderConfig: EncoderConfig{
+			CallerKey:     "caller",
+			StacktraceKey: "stacktrace",
+			EncodeLevel:   "capitalColor",
+			EncodeTime:    "ISO8601",
+		},
+		OutputPaths:      []string{Stdout},
+		ErrorOutputPaths: []string{Stderr},
+	}
+}
+
+// NewTestConfig returns the baseline for automated tests:  debug level console
+// output to stdout, in development mode, with callers and without colors, stacktraces,
+// or sampling.
+func NewTestConfig() Config {
+	return Config{
+		Level:             "debug",
+		Development:       true,
+		DisableStacktrace: true,
+		Encoding:          "console",
+		EncoderConfig: EncoderConfig{
+			CallerKey:   "caller",
+			EncodeLevel: "capital",
+		},
+		OutputPaths:      []string{Stdout},
+		ErrorOutputPaths: []string{Stderr},
+	}
+}
+
+// NewContainerConfig returns the baseline for services running in containers, whose
+// output is collected by the container runtime:  info level JSON output to stdout,
+// with callers, RFC3339 timestamps in UTC, stacktraces for errors, and the same sampling
+// as NewProductionConfig.  No files are written.
+func NewContainerConfig() Config {
+	return Config{
+		Level:    "info",
+		Encoding: "json",
+		Sampling: &zap.SamplingConfig{
+			Initial:    100,
+			Thereafter: 100,
+		},
+		EncoderConfig: EncoderConfig{
+			CallerKey:     "caller",
+			StacktraceKey: "stacktrace",
+			EncodeTime:    "RFC3339Nano",
+			UTC:           true,
+		},
+		OutputPaths:      []string{Stdout},
+		ErrorOutputPaths: []string{Stderr},
+	}
+}
+
+// presets maps each preset name onto its constructor.
+var presets = map[string]func() Config{
+	PresetProduction:  NewProductionConfig,
+	PresetDevelopment: NewDevelopmentConfig,
+	PresetTest:        NewTestConfig,
+	PresetContainer:   NewContainerConfig,
+}
+
+// PresetNames returns the sorted names of the available presets.
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// NewPresetConfig returns the Config for the named preset.  If there is no such preset,
+// this function returns a *ValidationError for the "preset" field.
+func NewPresetConfig(name string) (Config, error) {
+	if f, ok := presets[name]; ok {
+		return f(), nil
+	}
+
+	return Config{}, &ValidationError{
+		Path: "preset",
+		Err:  unknownValue(name),
+	}
+}
+
+// ApplyPreset returns this Config merged onto the Config for its Preset with Merge.  The
+// Preset and Explicit fields of the returned Config are cleared, so the preset is only ever
+// applied once.  If Preset is unset, this Config is returned as is.
+//
+// Each field of this Config that has a nonzero value overrides the preset, following the
+// rules of Merge:  slices such as OutputPaths replace the preset's value, maps such as
+// InitialFields are merged key by key, and Rotation and Sampling are merged field by field.
+// The fields listed in Explicit, along with the explicit paths passed to this method,
+// override the preset even when they hold zero values.  This is how a preset's boolean is
+// turned off or its Sampling removed, e.g. c.ApplyPreset("development", "samplingConfig").
+// An explicit path that does not name a field results in an error.
+//
+// Build, NewZapConfig, Validate, and the other methods that accept a Config with a Preset
+// apply it using Explicit, which is filled in when the Config is decoded from JSON or YAML.
+func (c Config) ApplyPreset(explicit ...string) (Config, error) {
+	if len(c.Preset) == 0 {
+		return c, nil
+	}
+
+	base, err := NewPresetConfig(c.Preset)
+	if err != nil {
+		return c, err
+	}
+
+	merged, err := base.Merge(c, explicit...)
+	if err != nil {
+		return c, err
+	}
+
+	merged.Preset = ""
+	merged.Explicit = nil
+	return merged, nil
+}
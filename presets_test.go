@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func testPresetNames(t *testing.T) {
+	assert.Equal(
+		t,
+		[]string{PresetContainer, PresetDevelopment, PresetProduction, PresetTest},
+		PresetNames(),
+	)
+}
+
+func testPresetsValid(t *testing.T) {
+	for _, name := range PresetNames() {
+		t.Run(name, func(t *testing.T) {
+			var (
+				assert  = assert.New(t)
+				require = require.New(t)
+			)
+
+			c, err := NewPresetConfig(name)
+			require.NoError(err)
+			assert.NoError(c.Validate())
+
+			zc, err := Config{Preset: name}.NewZapConfig()
+			require.NoError(err)
+			assert.Equal(c.Encoding, zc.Encoding)
+			assert.Equal(c.Development, zc.Development)
+			assert.Equal(c.OutputPaths, zc.OutputPaths)
+		})
+	}
+}
+
+func testPresetUnknown(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		ve     *ValidationError
+	)
+
+	_, err := NewPresetConfig("nosuchpreset")
+	assert.True(errors.As(err, &ve))
+	assert.Equal("preset", ve.Path)
+
+	_, err = Config{Preset: "nosuchpreset"}.ApplyPreset()
+	assert.True(errors.As(err, &ve))
+
+	assert.Equal(
+		[]string{"preset", "level"},
+		validationPaths(t, Config{Preset: "nosuchpreset", Level: "this is not a valid level"}.Validate()),
+	)
+
+	l, err := Config{Preset: "nosuchpreset"}.Build()
+	assert.Error(err)
+	assert.Nil(l)
+}
+
+func testApplyPreset(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		c       = Config{
+			Preset:      PresetContainer,
+			Level:       "debug",
+			OutputPaths: []string{"/var/log/app.log"},
+			Sampling: &zap.SamplingConfig{
+				Thereafter: 10,
+			},
+			EncoderConfig: EncoderConfig{
+				TimeZone:   "America/New_York",
+				MessageKey: "message",
+			},
+		}
+	)
+
+	merged, err := c.ApplyPreset()
+	require.NoError(err)
+	assert.Empty(merged.Preset)
+	assert.Equal("debug", merged.Level)
+	assert.Equal("json", merged.Encoding)
+	assert.Equal([]string{"/var/log/app.log"}, merged.OutputPaths)
+	assert.Equal([]string{Stderr}, merged.ErrorOutputPaths)
+	assert.Equal(&zap.SamplingConfig{Initial: 100, Thereafter: 10}, merged.Sampling)
+	assert.Equal("message", merged.EncoderConfig.MessageKey)
+	assert.Equal("caller", merged.EncoderConfig.CallerKey)
+	assert.Equal("RFC3339Nano", merged.EncoderConfig.EncodeTime)
+	assert.Equal("America/New_York", merged.EncoderConfig.TimeZone)
+	assert.False(merged.EncoderConfig.UTC)
+	assert.NoError(merged.Validate())
+
+	// no preset leaves the Config alone
+	unchanged, err := Config{Level: "warn"}.ApplyPreset()
+	require.NoError(err)
+	assert.Equal(Config{Level: "warn"}, unchanged)
+}
+
+func testApplyPresetExplicit(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		c       = Config{
+			Preset: PresetProduction,
+			Level:  "warn",
+		}
+	)
+
+	// explicit zero values override the preset
+	merged, err := Config{Preset: PresetDevelopment}.ApplyPreset("development")
+	require.NoError(err)
+	assert.False(merged.Development)
+	assert.Equal("console", merged.Encoding)
+
+	merged, err = c.ApplyPreset("samplingConfig")
+	require.NoError(err)
+	assert.Nil(merged.Sampling)
+	assert.Equal("warn", merged.Level)
+
+	// once applied, the preset is not applied again
+	l, lc, err := merged.BuildWithLevels()
+	require.NoError(err)
+	require.NotNil(l)
+	assert.Equal(zapcore.WarnLevel, lc.Level())
+
+	zc, err := merged.NewZapConfig()
+	require.NoError(err)
+	assert.Nil(zc.Sampling)
+
+	_, err = c.ApplyPreset("nosuchfield")
+	assert.Error(err)
+}
+
+func testApplyPresetDecoded(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		c       Config
+	)
+
+	// explicit zero values in a configuration file override the preset
+	require.NoError(json.Unmarshal([]byte(`{
+		"preset": "production",
+		"samplingConfig": null,
+		"disableStacktrace": false
+	}`), &c))
+
+	assert.Equal([]string{"disableStacktrace", "preset", "samplingConfig"}, c.Explicit)
+
+	zc, err := c.NewZapConfig()
+	require.NoError(err)
+	assert.Nil(zc.Sampling)
+	assert.False(zc.DisableStacktrace)
+	assert.Equal("json", zc.Encoding)
+
+	merged, err := c.ApplyPreset()
+	require.NoError(err)
+	assert.Nil(merged.Sampling)
+	assert.Empty(merged.Explicit)
+
+	// the same holds for YAML, and for configuration layered with MergeJSON
+	require.NoError(c.UnmarshalYAML(func(v interface{}) error {
+		return json.Unmarshal([]byte(`{"preset": "production", "samplingConfig": null}`), v)
+	}))
+
+	zc, err = c.NewZapConfig()
+	require.NoError(err)
+	assert.Nil(zc.Sampling)
+
+	layered, err := Config{Preset: PresetProduction}.MergeJSON([]byte(`{"samplingConfig": null}`))
+	require.NoError(err)
+	zc, err = layered.NewZapConfig()
+	require.NoError(err)
+	assert.Nil(zc.Sampling)
+
+	// fields that do not appear leave the preset alone
+	c = Config{}
+	require.NoError(json.Unmarshal([]byte(`{"preset": "production"}`), &c))
+	zc, err = c.NewZapConfig()
+	require.NoError(err)
+	assert.NotNil(zc.Sampling)
+}
+
+func testPresetBuild(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	l, lc, err := Config{Preset: PresetTest, Level: "warn"}.BuildWithLevels()
+	require.NoError(err)
+	require.NotNil(l)
+	assert.Equal(zapcore.WarnLevel, lc.Level())
+}
+
+func TestPresets(t *testing.T) {
+	t.Run("Names", testPresetNames)
+	t.Run("Valid", testPresetsValid)
+	t.Run("Unknown", testPresetUnknown)
+	t.Run("ApplyPreset", testApplyPreset)
+	t.Run("ApplyPresetExplicit", testApplyPresetExplicit)
+	t.Run("ApplyPresetDecoded", testApplyPresetDecoded)
+	t.Run("Build", testPresetBuild)
+}
@@ -0,0 +1,344 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// DefaultRedactReplacement is the value used for masked fields when
+	// RedactConfig.Replacement is unset.
+	DefaultRedactReplacement = "[REDACTED]"
+
+	// RedactHashPrefix is the prefix of the value used for hashed fields.  The
+	// remainder of the value is the hex-encoded SHA-256 of the field's original value.
+	RedactHashPrefix = "sha256:"
+)
+
+// RedactConfig describes the logging keys whose values must never be written as is.
+// Each entry in Mask, Hash, and Drop is a pattern that is matched against a field's
+// key, e.g. "password".  Patterns are matched case-insensitively, and may contain:
+//
+//   - dotted paths, such as "http.authorization", which refer to keys nested beneath a
+//     zap.Namespace, a zap.Object, or a field whose value is a map with string keys,
+//     such as a map[string]string or an http.Header.
+//   - glob wildcards as understood by path.Match, such as "*token*" or "device.*.secret".
+//
+// A pattern without dots matches a key at any depth, so "password" also redacts
+// "http.password".  A pattern also applies to every key nested beneath a key it matches,
+// so "headers" redacts an entire object.  When more than one pattern matches, Drop takes precedence
+// over Hash, which takes precedence over Mask.
+//
+// Redaction is applied to every core built from a Config, including the fields
+// added by With, such as those from InitialFields or from request loggers.
+type RedactConfig struct {
+	// Mask lists the patterns for fields whose values are replaced with Replacement.
+	Mask []string `json:"mask,omitempty" yaml:"mask,omitempty"`
+
+	// Hash lists the patterns for fields whose values are replaced with a SHA-256 hash,
+	// prefixed with RedactHashPrefix.  This allows values to be correlated across log
+	// entries without revealing them.
+	Hash []string `json:"hash,omitempty" yaml:"hash,omitempty"`
+
+	// Drop lists the patterns for fields that are removed entirely.
+	Drop []string `json:"drop,omitempty" yaml:"drop,omitempty"`
+
+	// Replacement is the value written in place of masked fields.  If unset,
+	// DefaultRedactReplacement is used.
+	Replacement string `json:"replacement,omitempty" yaml:"replacement,omitempty"`
+}
+
+// redactAction is what happens to a matching field.  Higher values take precedence.
+type redactAction int
+
+const (
+	redactNone redactAction = iota
+	redactMask
+	redactHash
+	redactDrop
+)
+
+type redactRule struct {
+	pattern string
+	action  redactAction
+}
+
+// redactor applies the rules from a RedactConfig to zap fields.
+type redactor struct {
+	rules       []redactRule
+	replacement string
+}
+
+// newRedactor creates a redactor for the given configuration.  If rc is nil or has no
+// patterns, this function returns nil.
+func newRedactor(rc *RedactConfig) *redactor {
+	if rc == nil || len(rc.Mask)+len(rc.Hash)+len(rc.Drop) == 0 {
+		return nil
+	}
+
+	r := &redactor{
+		replacement: rc.Replacement,
+	}
+
+	if len(r.replacement) == 0 {
+		r.replacement = DefaultRedactReplacement
+	}
+
+	add := func(patterns []string, action redactAction) {
+		for _, p := range patterns {
+			r.rules = append(r.rules, redactRule{pattern: strings.ToLower(p), action: action})
+		}
+	}
+
+	add(rc.Mask, redactMask)
+	add(rc.Hash, redactHash)
+	add(rc.Drop, redactDrop)
+	return r
+}
+
+// match returns the action for a dotted key path.  A rule matches if it matches
+// the path or any of the path's ancestors.  A rule without dots is also tried against
+// the last key of the path and of each ancestor.
+func (r *redactor) match(keyPath string) (action redactAction) {
+	keyPath = strings.ToLower(keyPath)
+	for _, rule := range r.rules {
+		if rule.action <= action {
+			continue
+		}
+
+		leaf := !strings.Contains(rule.pattern, ".")
+		for candidate := keyPath; ; {
+			i := strings.LastIndexByte(candidate, '.')
+			if ok, _ := path.Match(rule.pattern, candidate); ok {
+				action = rule.action
+				break
+			} else if ok, _ := path.Match(rule.pattern, candidate[i+1:]); ok && leaf {
+				action = rule.action
+				break
+			}
+
+			if i < 0 {
+				break
+			}
+
+			candidate = candidate[:i]
+		}
+	}
+
+	return
+}
+
+// hash computes the hashed representation of a value.
+func (r *redactor) hash(v interface{}) string {
+	sum := sha256.Sum256([]byte(fmt.Sprint(v)))
+	return RedactHashPrefix + hex.EncodeToString(sum[:])
+}
+
+// fieldValue returns the value of a field as it would be seen by an encoder.
+func fieldValue(f zapcore.Field) interface{} {
+	if f.Type == zapcore.StringType {
+		return f.String
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	return enc.Fields[f.Key]
+}
+
+// stringMap converts a map with string keys, such as a map[string]string or an http.Header,
+// into a map[string]interface{} so that its keys can be redacted.  Any other value is
+// reported as not being a map.
+func stringMap(v interface{}) (map[string]interface{}, bool) {
+	if m, ok := v.(map[string]interface{}); ok {
+		return m, true
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+
+	m := make(map[string]interface{}, rv.Len())
+	for iter := rv.MapRange(); iter.Next(); {
+		m[iter.Key().String()] = iter.Value().Interface()
+	}
+
+	return m, true
+}
+
+// redactMap applies the rules to the entries of a map nested at the given path.  If no
+// entry is redacted, the original map is returned.  Otherwise, a modified copy is returned.
+func (r *redactor) redactMap(prefix string, m map[string]interface{}) (map[string]interface{}, bool) {
+	var redacted map[string]interface{}
+	set := func(k string, v interface{}, drop bool) {
+		if redacted == nil {
+			redacted = make(map[string]interface{}, len(m))
+			for k, v := range m {
+				redacted[k] = v
+			}
+		}
+
+		if drop {
+			delete(redacted, k)
+		} else {
+			redacted[k] = v
+		}
+	}
+
+	// iterate in a fixed order so that results are deterministic
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	for _, k := range keys {
+		keyPath := prefix + "." + k
+		switch r.match(keyPath) {
+		case redactMask:
+			set(k, r.replacement, false)
+
+		case redactHash:
+			set(k, r.hash(m[k]), false)
+
+		case redactDrop:
+			set(k, nil, true)
+
+		default:
+			if nested, ok := stringMap(m[k]); ok {
+				if nested, changed := r.redactMap(keyPath, nested); changed {
+					set(k, nested, false)
+				}
+			}
+		}
+	}
+
+	if redacted == nil {
+		return m, false
+	}
+
+	return redacted, true
+}
+
+// redactNested applies the rules to the keys within an object or map field.  If nothing
+// within the field is redacted, the field is returned as is.
+func (r *redactor) redactNested(keyPath string, f zapcore.Field) (zapcore.Field, bool) {
+	var m map[string]interface{}
+	switch f.Type {
+	case zapcore.ObjectMarshalerType:
+		m, _ = fieldValue(f).(map[string]interface{})
+
+	case zapcore.ReflectType:
+		m, _ = stringMap(f.Interface)
+	}
+
+	if m == nil {
+		return f, false
+	}
+
+	if m, changed := r.redactMap(keyPath, m); changed {
+		return zap.Any(f.Key, m), true
+	}
+
+	return f, false
+}
+
+// redact applies the rules to a set of fields.  The prefix is the dotted path of the
+// namespace the fields are added to, and the returned prefix includes any namespaces
+// opened by the fields.  The original slice is never modified.
+func (r *redactor) redact(prefix string, fields []zapcore.Field) ([]zapcore.Field, string) {
+	var redacted []zapcore.Field
+	replace := func(i int, f *zapcore.Field) {
+		if redacted == nil {
+			redacted = make([]zapcore.Field, 0, len(fields))
+			redacted = append(redacted, fields[:i]...)
+		}
+
+		if f != nil {
+			redacted = append(redacted, *f)
+		}
+	}
+
+	for i, f := range fields {
+		keyPath := prefix + f.Key
+		switch action := r.match(keyPath); {
+		case f.Type == zapcore.NamespaceType:
+			// the namespace itself is kept, and the rules apply to the keys within it
+			prefix = keyPath + "."
+			if redacted != nil {
+				redacted = append(redacted, f)
+			}
+
+		case action == redactMask:
+			masked := zap.String(f.Key, r.replacement)
+			replace(i, &masked)
+
+		case action == redactHash:
+			hashed := zap.String(f.Key, r.hash(fieldValue(f)))
+			replace(i, &hashed)
+
+		case action == redactDrop:
+			replace(i, nil)
+
+		default:
+			if nested, changed := r.redactNested(keyPath, f); changed {
+				replace(i, &nested)
+			} else if redacted != nil {
+				redacted = append(redacted, f)
+			}
+		}
+	}
+
+	if redacted == nil {
+		return fields, prefix
+	}
+
+	return redacted, prefix
+}
+
+// redactCore decorates a zapcore.Core so that fields are redacted before they
+// reach the encoder.
+type redactCore struct {
+	zapcore.Core
+
+	redactor *redactor
+
+	// prefix is the dotted path of the namespace opened by any fields
+	// previously added with With
+	prefix string
+}
+
+var _ zapcore.Core = redactCore{}
+
+func (rc redactCore) With(fields []zapcore.Field) zapcore.Core {
+	fields, prefix := rc.redactor.redact(rc.prefix, fields)
+	return redactCore{
+		Core:     rc.Core.With(fields),
+		redactor: rc.redactor,
+		prefix:   prefix,
+	}
+}
+
+func (rc redactCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if rc.Enabled(ent.Level) {
+		return ce.AddCore(ent, rc)
+	}
+
+	return ce
+}
+
+func (rc redactCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	fields, _ = rc.redactor.redact(rc.prefix, fields)
+	return rc.Core.Write(ent, fields)
+}
@@ -0,0 +1,302 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type testCredentials struct {
+	User     string
+	Password string
+}
+
+func (tc testCredentials) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("user", tc.User)
+	enc.AddString("password", tc.Password)
+	return nil
+}
+
+// testRedactLogger builds a logger with the given redaction that writes JSON to a
+// temporary file.  The returned function syncs the logger and returns each log entry.
+func testRedactLogger(t *testing.T, rc *RedactConfig, initialFields map[string]interface{}) (*zap.Logger, func() []map[string]interface{}) {
+	file := filepath.Join(t.TempDir(), "redact.json")
+	l, err := Config{
+		OutputPaths:   []string{file},
+		InitialFields: initialFields,
+		Redact:        rc,
+	}.Build()
+
+	require.NoError(t, err)
+	return l, func() (entries []map[string]interface{}) {
+		require.NoError(t, l.Sync())
+		contents, err := os.ReadFile(file)
+		require.NoError(t, err)
+
+		for _, line := range strings.Split(strings.TrimSpace(string(contents)), "\n") {
+			var entry map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(line), &entry))
+			entries = append(entries, entry)
+		}
+
+		return
+	}
+}
+
+func testRedactHash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return RedactHashPrefix + hex.EncodeToString(sum[:])
+}
+
+func testRedactActions(t *testing.T) {
+	var (
+		assert    = assert.New(t)
+		l, result = testRedactLogger(
+			t,
+			&RedactConfig{
+				Mask: []string{"authorization", "*token*"},
+				Hash: []string{"deviceID"},
+				Drop: []string{"secret"},
+			},
+			map[string]interface{}{
+				"secret":  "initial secret",
+				"service": "test",
+			},
+		)
+	)
+
+	l.With(zap.String("Authorization", "Basic abc")).Info(
+		"message",
+		zap.String("accessToken", "xyz"),
+		zap.String("deviceID", "mac:112233445566"),
+		zap.Int("count", 1),
+	)
+
+	entries := result()
+	assert.Len(entries, 1)
+	assert.Equal(DefaultRedactReplacement, entries[0]["Authorization"])
+	assert.Equal(DefaultRedactReplacement, entries[0]["accessToken"])
+	assert.Equal(testRedactHash("mac:112233445566"), entries[0]["deviceID"])
+	assert.NotContains(entries[0], "secret")
+	assert.Equal("test", entries[0]["service"])
+	assert.Equal(float64(1), entries[0]["count"])
+}
+
+func testRedactPrecedence(t *testing.T) {
+	var (
+		assert    = assert.New(t)
+		l, result = testRedactLogger(
+			t,
+			&RedactConfig{
+				Mask:        []string{"*"},
+				Hash:        []string{"password"},
+				Drop:        []string{"pass*"},
+				Replacement: "xxx",
+			},
+			nil,
+		)
+	)
+
+	l.Info("message", zap.String("password", "p"), zap.String("user", "u"))
+	entries := result()
+	assert.NotContains(entries[0], "password")
+	assert.Equal("xxx", entries[0]["user"])
+	assert.Equal("message", entries[0]["msg"])
+}
+
+func testRedactNested(t *testing.T) {
+	var (
+		assert    = assert.New(t)
+		l, result = testRedactLogger(
+			t,
+			&RedactConfig{
+				Mask: []string{"http.authorization", "credentials.password", "headers"},
+				Drop: []string{"device.*.secret"},
+			},
+			nil,
+		)
+	)
+
+	l.With(zap.Namespace("http")).Info(
+		"message",
+		zap.String("authorization", "Bearer abc"),
+		zap.String("method", "GET"),
+	)
+
+	l.Info(
+		"message",
+		zap.Object("credentials", testCredentials{User: "joe", Password: "secret"}),
+		zap.Any("device", map[string]interface{}{
+			"wifi": map[string]interface{}{
+				"ssid":   "home",
+				"secret": "hunter2",
+			},
+		}),
+		zap.Any("headers", map[string]interface{}{
+			"X-Custom": "value",
+		}),
+	)
+
+	entries := result()
+	assert.Len(entries, 2)
+	assert.Equal(
+		map[string]interface{}{
+			"authorization": DefaultRedactReplacement,
+			"method":        "GET",
+		},
+		entries[0]["http"],
+	)
+
+	assert.Equal(
+		map[string]interface{}{
+			"user":     "joe",
+			"password": DefaultRedactReplacement,
+		},
+		entries[1]["credentials"],
+	)
+
+	assert.Equal(
+		map[string]interface{}{
+			"wifi": map[string]interface{}{
+				"ssid": "home",
+			},
+		},
+		entries[1]["device"],
+	)
+
+	assert.Equal(DefaultRedactReplacement, entries[1]["headers"])
+}
+
+func testRedactLeaf(t *testing.T) {
+	var (
+		assert    = assert.New(t)
+		l, result = testRedactLogger(
+			t,
+			&RedactConfig{
+				Mask: []string{"password", "authorization"},
+				Hash: []string{"*token"},
+			},
+			nil,
+		)
+	)
+
+	l.With(zap.Namespace("http")).Info(
+		"message",
+		zap.String("password", "hunter2"),
+		zap.String("accessToken", "abc"),
+		zap.Any("headers", http.Header{
+			"Authorization": {"Bearer abc"},
+			"Accept":        {"text/plain"},
+		}),
+		zap.Any("query", map[string]string{
+			"password": "hunter2",
+			"user":     "joe",
+		}),
+	)
+
+	entries := result()
+	assert.Len(entries, 1)
+	assert.NotContains(fmt.Sprint(entries[0]), "hunter2")
+	assert.Equal(
+		map[string]interface{}{
+			"password":    DefaultRedactReplacement,
+			"accessToken": testRedactHash("abc"),
+			"headers": map[string]interface{}{
+				"Authorization": DefaultRedactReplacement,
+				"Accept":        []interface{}{"text/plain"},
+			},
+			"query": map[string]interface{}{
+				"password": DefaultRedactReplacement,
+				"user":     "joe",
+			},
+		},
+		entries[0]["http"],
+	)
+}
+
+func testRedactCores(t *testing.T) {
+	var (
+		assert    = assert.New(t)
+		require   = require.New(t)
+		directory = t.TempDir()
+	)
+
+	l, err := Config{
+		Redact: &RedactConfig{
+			Mask: []string{"password"},
+		},
+		Cores: []CoreConfig{
+			{OutputPaths: []string{filepath.Join(directory, "first.json")}},
+			{OutputPaths: []string{filepath.Join(directory, "second.log")}, Encoding: "console"},
+		},
+	}.Build()
+
+	require.NoError(err)
+	l.Info("message", zap.String("password", "hunter2"))
+	require.NoError(l.Sync())
+
+	for _, name := range []string{"first.json", "second.log"} {
+		contents, err := os.ReadFile(filepath.Join(directory, name))
+		require.NoError(err)
+		assert.NotContains(string(contents), "hunter2")
+		assert.Contains(string(contents), DefaultRedactReplacement)
+	}
+}
+
+func testRedactUnchanged(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		r      = newRedactor(&RedactConfig{Mask: []string{"password"}})
+		fields = []zapcore.Field{zap.String("user", "joe"), zap.Int("count", 1)}
+	)
+
+	redacted, prefix := r.redact("", fields)
+	assert.Equal(fields, redacted)
+	assert.Empty(prefix)
+
+	// the original slice must not be modified
+	fields = append(fields, zap.String("password", "hunter2"))
+	redacted, _ = r.redact("", fields)
+	assert.Equal("hunter2", fields[2].String)
+	assert.Equal(DefaultRedactReplacement, redacted[2].String)
+
+	assert.Nil(newRedactor(nil))
+	assert.Nil(newRedactor(&RedactConfig{Replacement: "xxx"}))
+}
+
+func testRedactValidate(t *testing.T) {
+	assert.Equal(
+		t,
+		[]string{"redact.mask[1]", "redact.drop[0]"},
+		validationPaths(t, Config{
+			Redact: &RedactConfig{
+				Mask: []string{"password", "[unterminated"},
+				Drop: []string{"bad\\"},
+			},
+		}.Validate()),
+	)
+}
+
+func TestRedact(t *testing.T) {
+	t.Run("Actions", testRedactActions)
+	t.Run("Precedence", testRedactPrecedence)
+	t.Run("Nested", testRedactNested)
+	t.Run("Leaf", testRedactLeaf)
+	t.Run("Cores", testRedactCores)
+	t.Run("Unchanged", testRedactUnchanged)
+	t.Run("Validate", testRedactValidate)
+}
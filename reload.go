@@ -0,0 +1,372 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultWatchInterval is the polling interval used by FileWatcher when none is supplied.
+const DefaultWatchInterval = 5 * time.Second
+
+// generation is one build of the cores for a Reloader.  Writes hold the read
+// lock, so that retiring a generation waits for in-flight writes.
+type generation struct {
+	lock    sync.RWMutex
+	retired bool
+
+	core        zapcore.Core
+	errorOutput zapcore.WriteSyncer
+	rotate      func() error
+	closer      func()
+}
+
+// retire waits for any in-flight writes to this generation, then flushes
+// and closes its sinks.
+func (g *generation) retire() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.retired = true
+	g.core.Sync()
+	g.closer()
+}
+
+// Reloader controls a logger created with Config.BuildReloadable.  Reloading replaces
+// the cores, sinks, sampling, per-name levels, and initial fields of that logger.  Existing
+// *zap.Logger references, including ones created with With or Named, continue to work and
+// write to the new cores.
+//
+// Logger-wide options such as Development, DisableCaller, DisableStacktrace, and
+// ErrorOutputPaths are fixed when the logger is built and are not reloaded.
+//
+// A Reloader is safe for concurrent use.
+type Reloader struct {
+	lock    sync.Mutex
+	current atomic.Pointer[generation]
+	control *LevelControl
+}
+
+var _ Rotater = (*Reloader)(nil)
+
+// LevelControl returns the runtime level handle for the reloadable logger.  Each
+// Reload resets this handle to the levels in the new Config.
+func (r *Reloader) LevelControl() *LevelControl {
+	return r.control
+}
+
+// newGeneration builds the cores for a Config.  The zap.Config must be the one
+// produced by NewZapConfig, with its level replaced by the LevelControl's level.
+func (r *Reloader) newGeneration(c Config, zc zap.Config) (g *generation, err error) {
+	var res *coreResources
+	g = new(generation)
+	g.core, res, err = c.newCore(zc, r.control)
+	if err != nil {
+		return nil, err
+	}
+
+	var closeErrors func()
+	g.errorOutput, closeErrors, err = zap.Open(zc.ErrorOutputPaths...)
+	if err != nil {
+		res.close()
+		return nil, err
+	}
+
+	g.rotate = res.rotate
+	g.closer = func() {
+		res.close()
+		closeErrors()
+	}
+
+	if len(zc.InitialFields) > 0 {
+		g.core = g.core.With(initialFields(zc.InitialFields))
+	}
+
+	return g, nil
+}
+
+// Rotate rotates the Lumberjack sinks and reopens the plain files of the current
+// generation, so that it can be used with a SignalRotater.  Each file is handled even if
+// others fail, and all the errors are returned together.
+func (r *Reloader) Rotate() error {
+	g := r.acquire()
+	defer g.lock.RUnlock()
+	return g.rotate()
+}
+
+// acquire returns the current generation with its read lock held.  The
+// caller must release the read lock when finished writing.
+func (r *Reloader) acquire() *generation {
+	for {
+		g := r.current.Load()
+		g.lock.RLock()
+		if !g.retired {
+			return g
+		}
+
+		// this generation was replaced after we loaded it
+		g.lock.RUnlock()
+	}
+}
+
+// Reload rebuilds the logger's cores from the given Config.  If this method returns
+// an error, the logger is left unchanged.  Otherwise, the old sinks are closed once any
+// writes in progress finish.  Because the old sinks are still open while the new ones are
+// built, a Config that changes the rotation options of a file already being written to
+// is rejected.
+func (r *Reloader) Reload(c Config) error {
+	c, err := c.ApplyPreset()
+	if err != nil {
+		return err
+	}
+
+	zc, err := c.zapConfig()
+	if err != nil {
+		return err
+	}
+
+	names, err := parseNameLevels(c.Levels)
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// build the new cores against the existing level, so that the
+	// LevelControl keeps working across reloads
+	configured := zc.Level.Level()
+	zc.Level = r.control.AtomicLevel()
+	g, err := r.newGeneration(c, zc)
+	if err != nil {
+		return err
+	}
+
+	r.control.reset(configured, names)
+	r.current.Swap(g).retire()
+	return nil
+}
+
+// reloadCore is the zapcore.Core used by a reloadable logger.  It delegates to the
+// current generation of a Reloader.
+type reloadCore struct {
+	reloader *Reloader
+	fields   []zapcore.Field
+
+	// derived caches the current generation's core with fields applied
+	derived *atomic.Pointer[derivedCore]
+}
+
+// derivedCore is a generation's core with a reloadCore's fields applied.
+type derivedCore struct {
+	gen  *generation
+	core zapcore.Core
+}
+
+var _ zapcore.Core = reloadCore{}
+
+func newReloadCore(r *Reloader, fields []zapcore.Field) reloadCore {
+	return reloadCore{
+		reloader: r,
+		fields:   fields,
+		derived:  new(atomic.Pointer[derivedCore]),
+	}
+}
+
+// core returns the given generation's core with this instance's fields applied.
+func (rc reloadCore) core(g *generation) zapcore.Core {
+	if len(rc.fields) == 0 {
+		return g.core
+	}
+
+	if d := rc.derived.Load(); d != nil && d.gen == g {
+		return d.core
+	}
+
+	d := &derivedCore{
+		gen:  g,
+		core: g.core.With(rc.fields),
+	}
+
+	rc.derived.Store(d)
+	return d.core
+}
+
+func (rc reloadCore) Enabled(l zapcore.Level) bool {
+	return rc.reloader.current.Load().core.Enabled(l)
+}
+
+func (rc reloadCore) With(fields []zapcore.Field) zapcore.Core {
+	merged := make([]zapcore.Field, 0, len(rc.fields)+len(fields))
+	merged = append(merged, rc.fields...)
+	merged = append(merged, fields...)
+	return newReloadCore(rc.reloader, merged)
+}
+
+// Check only consults the level.  The current generation's own checks, such as
+// sampling and per-name levels, are performed by Write so that the entry is
+// never written to a generation that has been retired.
+func (rc reloadCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if rc.Enabled(ent.Level) {
+		return ce.AddCore(ent, rc)
+	}
+
+	return ce
+}
+
+func (rc reloadCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	g := rc.reloader.acquire()
+	defer g.lock.RUnlock()
+
+	if ce := rc.core(g).Check(ent, nil); ce != nil {
+		ce.ErrorOutput = g.errorOutput
+		ce.Write(fields...)
+	}
+
+	return nil
+}
+
+func (rc reloadCore) Sync() error {
+	g := rc.reloader.acquire()
+	defer g.lock.RUnlock()
+	return g.core.Sync()
+}
+
+// BuildReloadable is like BuildWithLevels, but returns a Reloader that can later rebuild
+// the logger's cores from a different Config.
+func (c Config) BuildReloadable(opts ...zap.Option) (l *zap.Logger, r *Reloader, err error) {
+	var (
+		zc    zap.Config
+		names map[string]zapcore.Level
+		g     *generation
+	)
+
+	c, err = c.ApplyPreset()
+	if err == nil {
+		zc, err = c.zapConfig()
+	}
+
+	if err == nil {
+		names, err = parseNameLevels(c.Levels)
+	}
+
+	if err == nil {
+		r = &Reloader{
+			control: NewLevelControl(zc.Level, names),
+		}
+
+		g, err = r.newGeneration(c, zc)
+	}
+
+	if err == nil {
+		r.current.Store(g)
+
+		// initial fields are part of each generation
+		zc.InitialFields = nil
+		l, err = newLogger(zc, newReloadCore(r, nil), opts...)
+		if err != nil {
+			g.closer()
+		}
+	}
+
+	if err != nil {
+		r = nil
+	}
+
+	return
+}
+
+// FileWatcher polls a configuration file and reloads a logger whenever that
+// file changes.
+type FileWatcher struct {
+	// Reloader is the reloadable logger to update.  This field is required.
+	Reloader *Reloader
+
+	// Path is the configuration file to watch.  This field is required.
+	Path string
+
+	// Interval is how often the file is checked for changes.  If unset,
+	// DefaultWatchInterval is used.
+	Interval time.Duration
+
+	// Decode converts the file's contents into a Config.  If unset,
+	// the file is unmarshaled as JSON.
+	Decode func([]byte) (Config, error)
+
+	// OnError is invoked with any error that occurs while reading the file or
+	// reloading the logger.  If unset, errors are ignored.
+	OnError func(error)
+}
+
+func decodeJSONConfig(data []byte) (c Config, err error) {
+	err = json.Unmarshal(data, &c)
+	return
+}
+
+// check reloads the logger if the file's contents differ from last.  The
+// file's current contents are returned.
+func (fw FileWatcher) check(decode func([]byte) (Config, error), last []byte) ([]byte, error) {
+	data, err := os.ReadFile(fw.Path)
+	if err != nil || bytes.Equal(data, last) {
+		return last, err
+	}
+
+	var c Config
+	c, err = decode(data)
+	if err == nil {
+		err = fw.Reloader.Reload(c)
+	}
+
+	// even when there is an error, remember these contents so that the same
+	// bad file is not reloaded at every interval
+	return data, err
+}
+
+// Run watches the file until the context is canceled, and always returns the context's error.
+// The logger is assumed to have been built from the file's contents at the time Run is called,
+// so the first reload happens only after the file changes.
+func (fw FileWatcher) Run(ctx context.Context) error {
+	var (
+		interval = fw.Interval
+		decode   = fw.Decode
+	)
+
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	if decode == nil {
+		decode = decodeJSONConfig
+	}
+
+	last, err := os.ReadFile(fw.Path)
+	if err != nil && fw.OnError != nil {
+		fw.OnError(err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-ticker.C:
+			last, err = fw.check(decode, last)
+			if err != nil && fw.OnError != nil {
+				fw.OnError(err)
+			}
+		}
+	}
+}
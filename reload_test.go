@@ -0,0 +1,240 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type ReloadSuite struct {
+	ZapcoreSuite
+}
+
+func (suite *ReloadSuite) logFile(name string) string {
+	return filepath.Join(suite.logDirectory, name)
+}
+
+func (suite *ReloadSuite) readLogFile(name string) string {
+	contents, err := os.ReadFile(suite.logFile(name))
+	suite.Require().NoError(err)
+	return string(contents)
+}
+
+func (suite *ReloadSuite) newConfig(name string, level string) Config {
+	return Config{
+		Level:       level,
+		OutputPaths: []string{suite.logFile(name)},
+		InitialFields: map[string]any{
+			"file": name,
+		},
+	}
+}
+
+func (suite *ReloadSuite) TestReload() {
+	l, r, err := suite.newConfig("first.json", "info").BuildReloadable()
+	suite.Require().NoError(err)
+	suite.Require().NotNil(l)
+	suite.Require().NotNil(r)
+
+	child := l.Named("child").With(zap.String("foo", "bar"))
+	child.Debug("first debug")
+	child.Info("first info")
+
+	suite.Require().NoError(r.Reload(suite.newConfig("second.json", "debug")))
+	suite.Equal(zapcore.DebugLevel, r.LevelControl().Level())
+	child.Debug("second debug")
+	suite.Require().NoError(l.Sync())
+
+	first := suite.readLogFile("first.json")
+	suite.NotContains(first, "first debug")
+	suite.Contains(first, "first info")
+	suite.Contains(first, `"file":"first.json"`)
+	suite.NotContains(first, "second")
+
+	second := suite.readLogFile("second.json")
+	suite.Contains(second, "second debug")
+	suite.Contains(second, `"foo":"bar"`)
+	suite.Contains(second, `"file":"second.json"`)
+	suite.NotContains(second, `"file":"first.json"`)
+}
+
+func (suite *ReloadSuite) TestReloadLevels() {
+	c := suite.newConfig("levels.json", "info")
+	c.Levels = map[string]string{"fx": "error"}
+
+	l, r, err := c.BuildReloadable()
+	suite.Require().NoError(err)
+
+	lc := r.LevelControl()
+	lc.SetLevel(zapcore.DebugLevel, time.Hour)
+	suite.Equal(map[string]zapcore.Level{"fx": zapcore.ErrorLevel}, lc.NameLevels())
+
+	c.Level = "warn"
+	c.Levels = map[string]string{"http": "debug"}
+	suite.Require().NoError(r.Reload(c))
+	suite.Equal(zapcore.WarnLevel, lc.Level())
+	suite.Equal(map[string]zapcore.Level{"http": zapcore.DebugLevel}, lc.NameLevels())
+
+	l.Info("discarded")
+	l.Named("http").Debug("http debug")
+	suite.Require().NoError(l.Sync())
+
+	contents := suite.readLogFile("levels.json")
+	suite.NotContains(contents, "discarded")
+	suite.Contains(contents, "http debug")
+}
+
+func (suite *ReloadSuite) TestReloadInvalid() {
+	l, r, err := suite.newConfig("valid.json", "info").BuildReloadable()
+	suite.Require().NoError(err)
+
+	testData := []Config{
+		{Level: "this is not a valid level"},
+		{Levels: map[string]string{"fx": "this is not a valid level"}},
+		{OutputPaths: []string{"nosuchscheme://foo"}},
+	}
+
+	for _, c := range testData {
+		suite.Error(r.Reload(c))
+	}
+
+	l.Info("still valid")
+	suite.Require().NoError(l.Sync())
+	suite.Contains(suite.readLogFile("valid.json"), "still valid")
+	suite.Equal(zapcore.InfoLevel, r.LevelControl().Level())
+}
+
+func (suite *ReloadSuite) TestReloadConcurrent() {
+	l, r, err := suite.newConfig("concurrent-0.json", "info").BuildReloadable()
+	suite.Require().NoError(err)
+
+	var (
+		wg   sync.WaitGroup
+		stop = make(chan struct{})
+	)
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger := l.With(zap.Int("writer", i))
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					logger.Info("concurrent")
+				}
+			}
+		}()
+	}
+
+	for i := 1; i <= 5; i++ {
+		suite.Require().NoError(
+			r.Reload(suite.newConfig("concurrent-"+strconv.Itoa(i)+".json", "info")),
+		)
+
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	close(stop)
+	wg.Wait()
+	suite.Require().NoError(l.Sync())
+
+	// every line in every file must be a complete JSON object
+	for i := 0; i <= 5; i++ {
+		contents := suite.readLogFile("concurrent-" + strconv.Itoa(i) + ".json")
+		for _, line := range strings.Split(strings.TrimSpace(contents), "\n") {
+			if len(line) > 0 {
+				suite.True(json.Valid([]byte(line)), line)
+			}
+		}
+	}
+}
+
+func (suite *ReloadSuite) TestBuildReloadableInvalid() {
+	l, r, err := Config{Level: "this is not a valid level"}.BuildReloadable()
+	suite.Error(err)
+	suite.Nil(l)
+	suite.Nil(r)
+}
+
+func (suite *ReloadSuite) writeConfig(path string, c Config) {
+	data, err := json.Marshal(c)
+	suite.Require().NoError(err)
+	suite.Require().NoError(os.WriteFile(path, data, 0600))
+}
+
+func (suite *ReloadSuite) TestFileWatcher() {
+	var (
+		configFile = suite.logFile("config.json")
+		initial    = suite.newConfig("watched-1.json", "info")
+	)
+
+	suite.writeConfig(configFile, initial)
+	l, r, err := initial.BuildReloadable()
+	suite.Require().NoError(err)
+
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		errs        = make(chan error, 10)
+		done        = make(chan error, 1)
+
+		fw = FileWatcher{
+			Reloader: r,
+			Path:     configFile,
+			Interval: 5 * time.Millisecond,
+			OnError: func(err error) {
+				errs <- err
+			},
+		}
+	)
+
+	defer cancel()
+	go func() {
+		done <- fw.Run(ctx)
+	}()
+
+	// give the watcher time to read the initial file
+	time.Sleep(50 * time.Millisecond)
+	suite.writeConfig(configFile, suite.newConfig("watched-2.json", "info"))
+	suite.Eventually(
+		func() bool {
+			l.Info("after reload")
+			l.Sync()
+			contents, _ := os.ReadFile(suite.logFile("watched-2.json"))
+			return strings.Contains(string(contents), "after reload")
+		},
+		time.Second,
+		10*time.Millisecond,
+	)
+
+	suite.Require().NoError(os.WriteFile(configFile, []byte("this is not JSON"), 0600))
+	select {
+	case err := <-errs:
+		suite.Error(err)
+	case <-time.After(time.Second):
+		suite.Fail("no error reported for an invalid configuration file")
+	}
+
+	cancel()
+	suite.True(errors.Is(<-done, context.Canceled))
+}
+
+func TestReload(t *testing.T) {
+	suite.Run(t, new(ReloadSuite))
+}
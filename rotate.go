@@ -0,0 +1,245 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"context"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// rotatableSink is an output sink that can rotate or reopen its file.
+type rotatableSink interface {
+	zap.Sink
+	Rotater
+}
+
+// ReopenFile is a zap.Sink for a plain file that can be reopened.  This allows external
+// tools such as logrotate to move the file aside and then signal the process, after which
+// writes go to a new file at the original path.  This type implements Rotater by reopening
+// the file.
+//
+// A ReopenFile is safe for concurrent writes.  No additional synchronization is required.
+type ReopenFile struct {
+	path  string
+	perms fs.FileMode
+
+	lock sync.Mutex
+	file *os.File
+}
+
+var _ rotatableSink = (*ReopenFile)(nil)
+
+// openFile opens a file for appending in the same way as zap's file sink.  A new file
+// is created with the given permissions, or with zap's 0666 if perms is 0.
+func openFile(path string, perms fs.FileMode) (*os.File, error) {
+	if perms == 0 {
+		perms = 0666
+	}
+
+	return os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, perms)
+}
+
+// NewReopenFile opens a file for appending, creating it if necessary.  The perms are
+// used whenever the file is created, including when it is reopened after being moved
+// aside.  If perms is 0, zap's default of 0666 is used.
+func NewReopenFile(path string, perms fs.FileMode) (*ReopenFile, error) {
+	f, err := openFile(path, perms)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReopenFile{
+		path:  path,
+		perms: perms,
+		file:  f,
+	}, nil
+}
+
+// Name returns the path of the file.
+func (rf *ReopenFile) Name() string {
+	return rf.path
+}
+
+func (rf *ReopenFile) Write(p []byte) (int, error) {
+	rf.lock.Lock()
+	defer rf.lock.Unlock()
+	return rf.file.Write(p)
+}
+
+func (rf *ReopenFile) Sync() error {
+	rf.lock.Lock()
+	defer rf.lock.Unlock()
+	return rf.file.Sync()
+}
+
+func (rf *ReopenFile) Close() error {
+	rf.lock.Lock()
+	defer rf.lock.Unlock()
+	return rf.file.Close()
+}
+
+// Rotate reopens the file at its original path.  If the file cannot be reopened, writes
+// continue to go to the current file.
+func (rf *ReopenFile) Rotate() error {
+	f, err := openFile(rf.path, rf.perms)
+	if err != nil {
+		return err
+	}
+
+	rf.lock.Lock()
+	defer rf.lock.Unlock()
+
+	rf.file.Close()
+	rf.file = f
+	return nil
+}
+
+// openRotatable opens the sink for an output path if that path is a lumberjack URL or
+// a plain file.  Plain files are created with the given permissions.  For any other path,
+// this function returns a nil sink.
+func openRotatable(path string, perms fs.FileMode) (rotatableSink, error) {
+	if path == Stdout || path == Stderr {
+		return nil, nil
+	}
+
+	if filepath.IsAbs(path) {
+		return NewReopenFile(path, perms)
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		// let zap report the problem
+		return nil, nil
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case LumberjackScheme:
+		sink, err := NewLumberjackSink(u)
+		if err != nil {
+			return nil, err
+		}
+
+		return sink.(rotatableSink), nil
+
+	case "", "file":
+		if len(u.Path) > 0 && (len(u.Host) == 0 || u.Host == "localhost") {
+			return NewReopenFile(u.Path, perms)
+		}
+	}
+
+	return nil, nil
+}
+
+// openPaths opens a set of output paths as a single zapcore.WriteSyncer.  Lumberjack URLs
+// and plain files are opened directly and registered with the coreResources for rotation.
+// All other paths are opened with zap.Open.  Plain files are created with the given permissions.
+//
+// The returned level is the lowest fsync level of any of the lumberjack URLs, or nil if
+// none of them have one.
+func openPaths(paths []string, perms fs.FileMode, res *coreResources) (ws zapcore.WriteSyncer, fsyncLevel *zapcore.Level, err error) {
+	var (
+		others  []string
+		syncers []zapcore.WriteSyncer
+	)
+
+	for _, p := range paths {
+		var sink rotatableSink
+		sink, err = openRotatable(p, perms)
+		if err != nil {
+			return
+		}
+
+		if sink == nil {
+			others = append(others, p)
+			continue
+		}
+
+		if lj, ok := sink.(Lumberjack); ok && lj.fsyncLevel != nil {
+			if fsyncLevel == nil || *lj.fsyncLevel < *fsyncLevel {
+				fsyncLevel = lj.fsyncLevel
+			}
+		}
+
+		res.onClose(func() { sink.Close() })
+		res.onRotate(sink)
+		syncers = append(syncers, sink)
+	}
+
+	if len(others) > 0 || len(syncers) == 0 {
+		var closer func()
+		ws, closer, err = zap.Open(others...)
+		if err != nil {
+			return
+		}
+
+		res.onClose(closer)
+		if len(syncers) == 0 {
+			return
+		}
+
+		syncers = append(syncers, ws)
+	}
+
+	ws = zapcore.Lock(zapcore.NewMultiWriteSyncer(syncers...))
+	return
+}
+
+// SignalRotater rotates a logger's files whenever the process receives a signal.  Lumberjack
+// sinks are rotated, and plain files are reopened.
+type SignalRotater struct {
+	// Rotater rotates the logger's files, such as a *Reloader.  This field is required.
+	Rotater Rotater
+
+	// Signals are the signals that trigger rotation.  If unset, SIGHUP is used.
+	Signals []os.Signal
+
+	// OnError is invoked with any error that occurs while rotating.  If unset,
+	// errors are ignored.
+	OnError func(error)
+}
+
+// notify starts relaying the signals to the returned channel.
+func (sr SignalRotater) notify() chan os.Signal {
+	signals := sr.Signals
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGHUP}
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, signals...)
+	return ch
+}
+
+// Run rotates the files each time one of the signals arrives, until the context is
+// canceled.  This method always returns the context's error.
+func (sr SignalRotater) Run(ctx context.Context) error {
+	ch := sr.notify()
+	defer signal.Stop(ch)
+	return sr.run(ctx, ch)
+}
+
+// run rotates the files for each signal received on the channel, until the context is canceled.
+func (sr SignalRotater) run(ctx context.Context, ch <-chan os.Signal) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-ch:
+			if err := sr.Rotater.Rotate(); err != nil && sr.OnError != nil {
+				sr.OnError(err)
+			}
+		}
+	}
+}
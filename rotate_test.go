@@ -0,0 +1,289 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// testRaise sends a signal to this process.
+func testRaise(t *testing.T, sig os.Signal) {
+	p, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, p.Signal(sig))
+}
+
+// testReadFile returns the contents of a file as a string.
+func testReadFile(t *testing.T, path string) string {
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(contents)
+}
+
+func testReopenFileRotate(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		dir     = t.TempDir()
+		path    = filepath.Join(dir, "test.log")
+		moved   = filepath.Join(dir, "test.log.1")
+	)
+
+	rf, err := NewReopenFile(path, 0)
+	require.NoError(err)
+	defer rf.Close()
+	assert.Equal(path, rf.Name())
+
+	_, err = rf.Write([]byte("before\n"))
+	require.NoError(err)
+
+	// the move-and-signal approach used by logrotate
+	require.NoError(os.Rename(path, moved))
+	_, err = rf.Write([]byte("moved\n"))
+	require.NoError(err)
+
+	require.NoError(rf.Rotate())
+	_, err = rf.Write([]byte("after\n"))
+	require.NoError(err)
+	require.NoError(rf.Sync())
+
+	assert.Equal("before\nmoved\n", testReadFile(t, moved))
+	assert.Equal("after\n", testReadFile(t, path))
+}
+
+func testReopenFileRotateError(t *testing.T) {
+	var (
+		require = require.New(t)
+		dir     = filepath.Join(t.TempDir(), "logs")
+		path    = filepath.Join(dir, "test.log")
+	)
+
+	require.NoError(os.Mkdir(dir, 0700))
+	rf, err := NewReopenFile(path, 0)
+	require.NoError(err)
+	defer rf.Close()
+
+	// the file cannot be reopened, so writes continue to the open file
+	require.NoError(os.RemoveAll(dir))
+	require.Error(rf.Rotate())
+	_, err = rf.Write([]byte("still open\n"))
+	require.NoError(err)
+}
+
+func TestReopenFile(t *testing.T) {
+	t.Run("Rotate", testReopenFileRotate)
+	t.Run("RotateError", testReopenFileRotateError)
+}
+
+func testRotatePermissions(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		dir     = t.TempDir()
+		path    = filepath.Join(dir, "perms.json")
+	)
+
+	l, _, res, err := Config{
+		OutputPaths: []string{path},
+		Permissions: "0600",
+	}.build()
+
+	require.NoError(err)
+	defer res.close()
+	l.Info("before")
+
+	// the reopened file has the same permissions as the original
+	require.NoError(os.Rename(path, filepath.Join(dir, "perms.json.1")))
+	require.NoError(res.rotate())
+	l.Info("after")
+
+	fi, err := os.Stat(path)
+	require.NoError(err)
+	assert.Equal(os.FileMode(0600), fi.Mode().Perm())
+}
+
+func testRotateConfig(t *testing.T) {
+	var (
+		assert     = assert.New(t)
+		require    = require.New(t)
+		dir        = t.TempDir()
+		plain      = filepath.Join(dir, "plain.json")
+		rotated    = filepath.Join(dir, "rotated.json")
+		moved      = filepath.Join(dir, "plain.json.1")
+		lumberjack = (&Rotation{MaxSize: 100}).NewURL(rotated).String()
+	)
+
+	l, _, res, err := Config{
+		OutputPaths: []string{plain, lumberjack, Stdout},
+	}.build()
+
+	require.NoError(err)
+	defer res.close()
+	l.Info("before")
+
+	require.NoError(os.Rename(plain, moved))
+	require.NoError(res.rotate())
+	l.Info("after")
+
+	assert.Contains(testReadFile(t, moved), "before")
+	assert.NotContains(testReadFile(t, moved), "after")
+	assert.Contains(testReadFile(t, plain), "after")
+	assert.Contains(testReadFile(t, rotated), "after")
+	assert.NotContains(testReadFile(t, rotated), "before")
+	assert.Len(testBackups(t, rotated), 1)
+}
+
+func testRotateSharedFile(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		dir     = t.TempDir()
+		path    = filepath.Join(dir, "shared.json")
+	)
+
+	// both output paths name the same file, which must be rotated only once
+	l, _, res, err := Config{
+		OutputPaths: []string{path, dir + "/./shared.json"},
+		Rotation:    &Rotation{MaxSize: 100},
+	}.build()
+
+	require.NoError(err)
+	defer res.close()
+	l.Info("before")
+
+	require.NoError(res.rotate())
+	l.Info("after")
+
+	backups := testBackups(t, path)
+	require.Len(backups, 1)
+	assert.Contains(testReadFile(t, backups[0]), "before")
+	assert.NotContains(testReadFile(t, backups[0]), "after")
+	assert.Contains(testReadFile(t, path), "after")
+	assert.NotContains(testReadFile(t, path), "before")
+}
+
+func testRotateReloader(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		dir     = t.TempDir()
+		first   = filepath.Join(dir, "first.json")
+		second  = filepath.Join(dir, "second.json")
+	)
+
+	l, r, err := Config{OutputPaths: []string{first}}.BuildReloadable()
+	require.NoError(err)
+	require.NoError(r.Reload(Config{OutputPaths: []string{second}}))
+	l.Info("before")
+
+	// only the current generation's files are reopened
+	require.NoError(os.Remove(second))
+	require.NoError(os.Remove(first))
+	require.NoError(r.Rotate())
+	l.Info("after")
+
+	assert.Contains(testReadFile(t, second), "after")
+	assert.NoFileExists(first)
+}
+
+// testRotater counts rotations, failing every other one.
+type testRotater struct {
+	count atomic.Int32
+}
+
+func (tr *testRotater) Rotate() error {
+	if tr.count.Add(1)%2 == 0 {
+		return errors.New("expected")
+	}
+
+	return nil
+}
+
+func testSignalRotaterRun(t *testing.T) {
+	var (
+		assert      = assert.New(t)
+		tr          = new(testRotater)
+		errs        atomic.Int32
+		ctx, cancel = context.WithCancel(context.Background())
+		done        = make(chan error)
+	)
+
+	sr := SignalRotater{
+		Rotater: tr,
+		Signals: []os.Signal{syscall.SIGHUP},
+		OnError: func(error) { errs.Add(1) },
+	}
+
+	ch := sr.notify()
+	defer signal.Stop(ch)
+	go func() {
+		done <- sr.run(ctx, ch)
+	}()
+
+	for i := int32(1); i <= 2; i++ {
+		testRaise(t, syscall.SIGHUP)
+		assert.Eventually(
+			func() bool { return tr.count.Load() == i },
+			time.Second,
+			10*time.Millisecond,
+		)
+	}
+
+	assert.Equal(int32(1), errs.Load())
+	cancel()
+	assert.ErrorIs(<-done, context.Canceled)
+}
+
+func testOpenPathsFsyncLevel(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		dir     = t.TempDir()
+		res     = new(coreResources)
+	)
+
+	defer res.close()
+	_, fsyncLevel, err := openPaths(
+		[]string{
+			(&Rotation{FsyncLevel: "error"}).NewURL(filepath.Join(dir, "error.log")).String(),
+			(&Rotation{FsyncLevel: "warn"}).NewURL(filepath.Join(dir, "warn.log")).String(),
+			(&Rotation{Fsync: true}).NewURL(filepath.Join(dir, "fsync.log")).String(),
+			filepath.Join(dir, "plain.log"),
+		},
+		0,
+		res,
+	)
+
+	require.NoError(err)
+	require.NotNil(fsyncLevel)
+	assert.Equal(zapcore.WarnLevel, *fsyncLevel)
+
+	_, fsyncLevel, err = openPaths([]string{filepath.Join(dir, "plain.log")}, 0, res)
+	require.NoError(err)
+	assert.Nil(fsyncLevel)
+}
+
+func TestOpenPaths(t *testing.T) {
+	t.Run("FsyncLevel", testOpenPathsFsyncLevel)
+}
+
+func TestRotate(t *testing.T) {
+	t.Run("Config", testRotateConfig)
+	t.Run("SharedFile", testRotateSharedFile)
+	t.Run("Permissions", testRotatePermissions)
+	t.Run("Reloader", testRotateReloader)
+	t.Run("SignalRotater", testSignalRotaterRun)
+}
@@ -0,0 +1,378 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// backupTimeFormat is the layout lumberjack uses for the timestamp in the name of a backup.
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+
+	// compressSuffix is the extension lumberjack adds to compressed backups.
+	compressSuffix = ".gz"
+)
+
+// RotationEventType identifies what happened to a lumberjack file or one of its backups.
+type RotationEventType string
+
+const (
+	// RotationRotated is the event for a file that was moved aside to a new backup.
+	RotationRotated RotationEventType = "rotated"
+
+	// RotationCompressed is the event for a backup that has been compressed.  The
+	// uncompressed backup has been removed.
+	RotationCompressed RotationEventType = "compressed"
+
+	// RotationDeleted is the event for a backup that was removed because of the
+	// MaxBackups or MaxAge options.  Backups that are removed by anything else while
+	// lumberjack is removing backups are reported in the same way.
+	RotationDeleted RotationEventType = "deleted"
+)
+
+// RotationEvent describes something that happened to a file written by a Lumberjack sink.
+// All paths are absolute.
+type RotationEvent struct {
+	// Type is what happened.
+	Type RotationEventType
+
+	// Filename is the file that the Lumberjack sinks write to.
+	Filename string
+
+	// Backup is the backup that the event concerns:  the new backup for RotationRotated,
+	// the compressed backup for RotationCompressed, and the removed backup for RotationDeleted.
+	Backup string
+
+	// Uncompressed is the backup that was compressed into Backup.  This field is only set
+	// for RotationCompressed, and the file it names no longer exists.
+	Uncompressed string
+}
+
+// rotationHook is a registered hook.  Hooks are tracked by pointer so that they can be removed.
+type rotationHook struct {
+	f func(RotationEvent)
+}
+
+// OnRotation registers a hook that is called for each RotationEvent of every file in this
+// registry, for example to upload finished backups or keep an audit trail of deleted ones.
+// The returned function removes the hook.
+//
+// Each rotation is reported by the Write or Rotate call that made it, before that call returns
+// but after the file is unlocked, so a slow hook delays that call.  If lumberjack compresses or
+// removes backups, those events are reported from a background goroutine that watches the
+// backups after the file is first opened and after each rotation.  It stops once the backups
+// have settled, after 10 seconds without a change, or when the file is closed.  Only the backups
+// that lumberjack made or found when the file was opened are reported, never those created later
+// by anything else.  Each backup's events are reported in the order they happen.
+func (lr *LumberjackRegistry) OnRotation(f func(RotationEvent)) (remove func()) {
+	hook := &rotationHook{f: f}
+	lr.hooksLock.Lock()
+	lr.hooks = append(lr.hooks, hook)
+	lr.hooksLock.Unlock()
+
+	return func() {
+		lr.hooksLock.Lock()
+		defer lr.hooksLock.Unlock()
+
+		for i, h := range lr.hooks {
+			if h == hook {
+				lr.hooks = append(lr.hooks[:i:i], lr.hooks[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// dispatch calls each hook with an event.
+func (lr *LumberjackRegistry) dispatch(e RotationEvent) {
+	lr.hooksLock.Lock()
+	hooks := lr.hooks
+	lr.hooksLock.Unlock()
+
+	for _, h := range hooks {
+		h.f(e)
+	}
+}
+
+// lumberjackBackup is a backup of a lumberjack file.
+type lumberjackBackup struct {
+	name      string
+	timestamp time.Time
+}
+
+// lumberjackBackups lists the backups of a file in the same way as lumberjack, newest first.
+func lumberjackBackups(filename string) ([]lumberjackBackup, error) {
+	entries, err := os.ReadDir(filepath.Dir(filename))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		base   = filepath.Base(filename)
+		ext    = filepath.Ext(base)
+		prefix = base[:len(base)-len(ext)] + "-"
+
+		backups []lumberjackBackup
+	)
+
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+
+		ts := strings.TrimPrefix(e.Name(), prefix)
+		if trimmed, ok := strings.CutSuffix(ts, ext); ok {
+			ts = trimmed
+		} else if trimmed, ok := strings.CutSuffix(ts, ext+compressSuffix); ok {
+			ts = trimmed
+		} else {
+			continue
+		}
+
+		if t, err := time.Parse(backupTimeFormat, ts); err == nil {
+			backups = append(backups, lumberjackBackup{name: e.Name(), timestamp: t})
+		}
+	}
+
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].timestamp.After(backups[j].timestamp)
+	})
+
+	return backups, nil
+}
+
+// backupState records which versions of a backup exist.
+type backupState uint8
+
+const (
+	// backupPlain is set if the uncompressed backup exists.
+	backupPlain backupState = 1 << iota
+
+	// backupCompressed is set if the compressed backup exists.
+	backupCompressed
+)
+
+// backupStates lists the backups of a file, keyed by the absolute path of each
+// uncompressed backup whether or not it has been compressed.
+func backupStates(filename string) (map[string]backupState, error) {
+	backups, err := lumberjackBackups(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		dir    = filepath.Dir(filename)
+		states = make(map[string]backupState, len(backups))
+	)
+
+	for _, b := range backups {
+		if name, ok := strings.CutSuffix(b.name, compressSuffix); ok {
+			states[filepath.Join(dir, name)] |= backupCompressed
+		} else {
+			states[filepath.Join(dir, name)] |= backupPlain
+		}
+	}
+
+	return states, nil
+}
+
+// rotationBackup returns the backup that a rotation between start and end moved a file to,
+// or the empty string if there is no such backup.  lumberjack names the backup for the time
+// of the rotation, to the millisecond, so only the names for times within the rotation are
+// tried.  Of those, the backup is the one that is still the old file.  If lumberjack has
+// already compressed the backup, it is the compressed backup with one of those names.
+func rotationBackup(filename string, old os.FileInfo, start, end time.Time, local bool) string {
+	var (
+		ext    = filepath.Ext(filename)
+		prefix = strings.TrimSuffix(filename, ext) + "-"
+
+		compressed string
+	)
+
+	if local {
+		start, end = start.Local(), end.Local()
+	} else {
+		start, end = start.UTC(), end.UTC()
+	}
+
+	for t := start.Truncate(time.Millisecond); !t.After(end); t = t.Add(time.Millisecond) {
+		backup := prefix + t.Format(backupTimeFormat) + ext
+		if fi, err := os.Lstat(backup); err == nil && os.SameFile(old, fi) {
+			return backup
+		}
+
+		if _, err := os.Lstat(backup + compressSuffix); err == nil && len(compressed) == 0 {
+			compressed = backup
+		}
+	}
+
+	return compressed
+}
+
+// trackedBackup is a backup followed by the watch goroutine.
+type trackedBackup struct {
+	state backupState
+	gen   uint64
+}
+
+const (
+	// watchInterval is how often the watch goroutine lists a file's backups.
+	watchInterval = 50 * time.Millisecond
+
+	// watchSettle is how long the backups must stay the same before the watch goroutine
+	// decides that lumberjack is done with them.
+	watchSettle = 500 * time.Millisecond
+
+	// watchTimeout is how long the watch goroutine waits on a compression that never
+	// happens, for example because it failed.  Anything lumberjack does to the backups
+	// after this is not reported.
+	watchTimeout = 10 * time.Second
+)
+
+// track starts following backups that are not already followed.  The lock must be held.
+func (lf *lumberjackFile) track(states map[string]backupState) {
+	if lf.backups == nil {
+		lf.backups = make(map[string]trackedBackup)
+	}
+
+	lf.gen++
+	for backup, state := range states {
+		if _, ok := lf.backups[backup]; !ok {
+			lf.backups[backup] = trackedBackup{state: state, gen: lf.gen}
+		}
+	}
+}
+
+// milling tests if lumberjack compresses or removes this file's backups.  The lock must be held.
+func (lf *lumberjackFile) milling() bool {
+	return lf.logger.MaxBackups > 0 || lf.logger.MaxAge > 0 || lf.logger.Compress
+}
+
+// compressing tests if lumberjack has yet to compress some of the followed backups.  The lock must be held.
+func (lf *lumberjackFile) compressing() bool {
+	if lf.logger.Compress {
+		for _, tb := range lf.backups {
+			if tb.state == backupPlain {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// poke notes that lumberjack may be milling the backups, starting the watch goroutine
+// if necessary.  The lock must be held.
+func (lf *lumberjackFile) poke() {
+	lf.poked = true
+	if !lf.watching {
+		lf.watching = true
+		go lf.watch()
+	}
+}
+
+// diff compares the followed backups with a listing taken after the given generation, and
+// returns the events for the differences.  Backups found after the listing began are left
+// for the next listing.  The lock must be held.
+func (lf *lumberjackFile) diff(filename string, current map[string]backupState, gen uint64) (events []RotationEvent) {
+	for backup, tb := range lf.backups {
+		state := current[backup]
+		if tb.gen > gen || tb.state == state {
+			continue
+		}
+
+		switch {
+		case state == 0:
+			deleted := backup
+			if tb.state&backupPlain == 0 {
+				deleted += compressSuffix
+			}
+
+			delete(lf.backups, backup)
+			events = append(events, RotationEvent{Type: RotationDeleted, Filename: filename, Backup: deleted})
+			continue
+
+		case state == backupCompressed && tb.state&backupPlain != 0:
+			events = append(events, RotationEvent{
+				Type:         RotationCompressed,
+				Filename:     filename,
+				Backup:       backup + compressSuffix,
+				Uncompressed: backup,
+			})
+		}
+
+		lf.backups[backup] = trackedBackup{state: state, gen: tb.gen}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Backup < events[j].Backup
+	})
+
+	return
+}
+
+// watch follows the backups while lumberjack compresses and removes them, until the
+// backups have settled or the file is closed.
+func (lf *lumberjackFile) watch() {
+	var (
+		last    map[string]backupState
+		changed = time.Now()
+		closed  bool
+	)
+
+	for {
+		lf.lock.Lock()
+		gen := lf.gen
+		filename := lumberjackName(lf.logger.Filename)
+		lf.lock.Unlock()
+
+		current, err := backupStates(filename)
+
+		lf.lock.Lock()
+		var events []RotationEvent
+		if err == nil {
+			events = lf.diff(filename, current, gen)
+		}
+
+		now := time.Now()
+		if lf.poked || !maps.Equal(last, current) {
+			lf.poked = false
+			changed = now
+		}
+
+		last = current
+		idle := now.Sub(changed)
+		done := closed || !lf.milling() || idle >= watchTimeout || (idle >= watchSettle && !lf.compressing())
+		lf.lock.Unlock()
+
+		for _, e := range events {
+			lf.registry.dispatch(e)
+		}
+
+		if done {
+			lf.lock.Lock()
+			if closed || !lf.poked {
+				lf.watching = false
+				lf.lock.Unlock()
+				return
+			}
+
+			lf.lock.Unlock()
+		}
+
+		select {
+		case <-lf.done:
+			// one last listing reports what lumberjack has done so far
+			closed = true
+
+		case <-time.After(watchInterval):
+		}
+	}
+}
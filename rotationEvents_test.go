@@ -0,0 +1,392 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// testRotationEvents registers a hook with a new registry, returning the registry and
+// a channel that receives the events.
+func testRotationEvents(t *testing.T) (*LumberjackRegistry, <-chan RotationEvent) {
+	var (
+		lr     = newLumberjackRegistry()
+		events = make(chan RotationEvent, 100)
+	)
+
+	t.Cleanup(lr.OnRotation(func(e RotationEvent) { events <- e }))
+	return lr, events
+}
+
+// testNextEvent waits for the next event.
+func testNextEvent(t *testing.T, events <-chan RotationEvent) RotationEvent {
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no rotation event")
+		return RotationEvent{}
+	}
+}
+
+// testNoEvents asserts that no more events arrive.
+func testNoEvents(t *testing.T, events <-chan RotationEvent) {
+	select {
+	case e := <-events:
+		assert.Fail(t, "unexpected rotation event", "%#v", e)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// testWatching tests if a sink's file has a watch goroutine.
+func testWatching(lj Lumberjack) bool {
+	lj.ref.file.lock.Lock()
+	defer lj.ref.file.lock.Unlock()
+	return lj.ref.file.watching
+}
+
+func testRotationEventsRotate(t *testing.T) {
+	var (
+		assert     = assert.New(t)
+		require    = require.New(t)
+		lr, events = testRotationEvents(t)
+		filename   = lumberjackName(filepath.Join(t.TempDir(), "rotate.log"))
+		lj         = testLumberjackOpen(t, lr, &lumberjack.Logger{Filename: filename})
+		_, err     = lj.Write([]byte("before\n"))
+	)
+
+	require.NoError(err)
+	defer lj.Close()
+	require.NoError(lj.Rotate())
+
+	// the rotation is reported before Rotate returns, and without a watch goroutine
+	// since lumberjack does not mill these backups
+	require.Len(events, 1)
+	assert.False(testWatching(lj))
+
+	e := <-events
+	assert.Equal(RotationRotated, e.Type)
+	assert.Equal(filename, e.Filename)
+	assert.Empty(e.Uncompressed)
+
+	assert.Equal(testBackups(t, filename), []string{e.Backup})
+	assert.Equal("before\n", testReadFile(t, e.Backup))
+	testNoEvents(t, events)
+}
+
+func testRotationEventsSize(t *testing.T) {
+	var (
+		assert     = assert.New(t)
+		require    = require.New(t)
+		lr, events = testRotationEvents(t)
+		filename   = lumberjackName(filepath.Join(t.TempDir(), "size.log"))
+		lj         = testLumberjackOpen(t, lr, &lumberjack.Logger{Filename: filename, MaxSize: 1})
+		entry      = bytes.Repeat([]byte{'x'}, 700*1024)
+	)
+
+	defer lj.Close()
+	for i := 0; i < 2; i++ {
+		n, err := lj.Write(entry)
+		require.NoError(err)
+		assert.Equal(len(entry), n)
+	}
+
+	e := testNextEvent(t, events)
+	assert.Equal(RotationRotated, e.Type)
+	assert.Equal(testBackups(t, filename), []string{e.Backup})
+
+	_, err := lj.Write(bytes.Repeat([]byte{'x'}, megabyte+1))
+	assert.Error(err)
+	testNoEvents(t, events)
+}
+
+func testRotationEventsCompress(t *testing.T) {
+	var (
+		assert     = assert.New(t)
+		require    = require.New(t)
+		lr, events = testRotationEvents(t)
+		filename   = lumberjackName(filepath.Join(t.TempDir(), "compress.log"))
+		lj         = testLumberjackOpen(t, lr, &lumberjack.Logger{Filename: filename, Compress: true})
+		_, err     = lj.Write([]byte("compressed\n"))
+	)
+
+	require.NoError(err)
+	defer lj.Close()
+	require.NoError(lj.Rotate())
+
+	rotated := testNextEvent(t, events)
+	assert.Equal(RotationRotated, rotated.Type)
+
+	compressed := testNextEvent(t, events)
+	assert.Equal(RotationCompressed, compressed.Type)
+	assert.Equal(filename, compressed.Filename)
+	assert.Equal(rotated.Backup, compressed.Uncompressed)
+	assert.Equal(rotated.Backup+compressSuffix, compressed.Backup)
+	assert.NoFileExists(compressed.Uncompressed)
+
+	f, err := os.Open(compressed.Backup)
+	require.NoError(err)
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	require.NoError(err)
+	contents, err := io.ReadAll(gz)
+	require.NoError(err)
+	assert.Equal("compressed\n", string(contents))
+}
+
+func testRotationEventsCompressRepeatedly(t *testing.T) {
+	var (
+		assert     = assert.New(t)
+		require    = require.New(t)
+		lr, events = testRotationEvents(t)
+		filename   = lumberjackName(filepath.Join(t.TempDir(), "repeated.log"))
+		lj         = testLumberjackOpen(t, lr, &lumberjack.Logger{Filename: filename, Compress: true})
+		rotated    = make(map[string]bool)
+		compressed = make(map[string]bool)
+	)
+
+	defer lj.Close()
+
+	// earlier backups are compressed while later rotations happen, and must
+	// never be mistaken for the new backups
+	for i := 0; i < 5; i++ {
+		_, err := lj.Write([]byte("entry\n"))
+		require.NoError(err)
+		require.NoError(lj.Rotate())
+
+		// backups are named to the millisecond
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	for len(rotated) < 5 || len(compressed) < 5 {
+		e := testNextEvent(t, events)
+		switch e.Type {
+		case RotationRotated:
+			assert.False(strings.HasSuffix(e.Backup, compressSuffix))
+			assert.False(rotated[e.Backup])
+			rotated[e.Backup] = true
+
+		case RotationCompressed:
+			assert.True(rotated[e.Uncompressed], "compressed before rotated")
+			assert.False(compressed[e.Uncompressed])
+			compressed[e.Uncompressed] = true
+
+		default:
+			assert.Fail("unexpected event", "%#v", e)
+		}
+	}
+
+	assert.Equal(rotated, compressed)
+	testNoEvents(t, events)
+
+	// the watch goroutine exits once the backups have settled
+	assert.Eventually(
+		func() bool { return !testWatching(lj) },
+		5*time.Second,
+		10*time.Millisecond,
+	)
+}
+
+func testRotationEventsClose(t *testing.T) {
+	var (
+		assert     = assert.New(t)
+		require    = require.New(t)
+		lr, events = testRotationEvents(t)
+		filename   = lumberjackName(filepath.Join(t.TempDir(), "close.log"))
+		lj         = testLumberjackOpen(t, lr, &lumberjack.Logger{Filename: filename, MaxBackups: 10})
+		_, err     = lj.Write([]byte("entry\n"))
+	)
+
+	require.NoError(err)
+	require.NoError(lj.Rotate())
+	require.Equal(RotationRotated, testNextEvent(t, events).Type)
+	require.True(testWatching(lj))
+
+	// closing stops the watch goroutine well before the backups would settle
+	require.NoError(lj.Close())
+	assert.Eventually(
+		func() bool { return !testWatching(lj) },
+		watchSettle/2,
+		5*time.Millisecond,
+	)
+
+	testNoEvents(t, events)
+}
+
+func testRotationEventsForeign(t *testing.T) {
+	var (
+		assert     = assert.New(t)
+		require    = require.New(t)
+		lr, events = testRotationEvents(t)
+		dir        = t.TempDir()
+		filename   = lumberjackName(filepath.Join(dir, "foreign.log"))
+		lj         = testLumberjackOpen(t, lr, &lumberjack.Logger{Filename: filename, Compress: true})
+		_, err     = lj.Write([]byte("entry\n"))
+	)
+
+	require.NoError(err)
+	defer lj.Close()
+
+	// a backup that something else creates after the file was opened is never reported,
+	// even though lumberjack compresses it along with the sink's own backup
+	foreign := filepath.Join(dir, "foreign-"+time.Now().UTC().Format(backupTimeFormat)+".log")
+	require.NoError(os.WriteFile(foreign, []byte("foreign\n"), 0600))
+	time.Sleep(5 * time.Millisecond)
+	require.NoError(lj.Rotate())
+
+	rotated := testNextEvent(t, events)
+	assert.Equal(RotationRotated, rotated.Type)
+	assert.NotEqual(foreign, rotated.Backup)
+	assert.Equal("entry\n", testReadFile(t, rotated.Backup))
+
+	compressed := testNextEvent(t, events)
+	assert.Equal(RotationCompressed, compressed.Type)
+	assert.Equal(rotated.Backup, compressed.Uncompressed)
+
+	assert.Eventually(
+		func() bool { return !testWatching(lj) },
+		5*time.Second,
+		10*time.Millisecond,
+	)
+
+	assert.FileExists(foreign + compressSuffix)
+	testNoEvents(t, events)
+}
+
+func testRotationEventsMaxBackups(t *testing.T) {
+	var (
+		assert     = assert.New(t)
+		require    = require.New(t)
+		lr, events = testRotationEvents(t)
+		filename   = lumberjackName(filepath.Join(t.TempDir(), "maxBackups.log"))
+		lj         = testLumberjackOpen(t, lr, &lumberjack.Logger{Filename: filename, MaxBackups: 1})
+		rotated    []string
+	)
+
+	defer lj.Close()
+	for i := 0; i < 2; i++ {
+		_, err := lj.Write([]byte("entry\n"))
+		require.NoError(err)
+		require.NoError(lj.Rotate())
+
+		e := testNextEvent(t, events)
+		require.Equal(RotationRotated, e.Type)
+		rotated = append(rotated, e.Backup)
+
+		// backups are named to the millisecond
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	e := testNextEvent(t, events)
+	assert.Equal(RotationDeleted, e.Type)
+	assert.Equal(filename, e.Filename)
+	assert.Equal(rotated[0], e.Backup)
+	assert.NoFileExists(rotated[0])
+	assert.FileExists(rotated[1])
+}
+
+func testRotationEventsMaxAge(t *testing.T) {
+	var (
+		assert     = assert.New(t)
+		require    = require.New(t)
+		lr, events = testRotationEvents(t)
+		dir        = t.TempDir()
+		filename   = lumberjackName(filepath.Join(dir, "maxAge.log"))
+		old        = filepath.Join(
+			filepath.Dir(filename),
+			"maxAge-"+time.Now().UTC().AddDate(0, 0, -10).Format(backupTimeFormat)+".log",
+		)
+	)
+
+	// backups left from before are removed once the file is first written
+	require.NoError(os.WriteFile(old, []byte("old\n"), 0600))
+	lj := testLumberjackOpen(t, lr, &lumberjack.Logger{Filename: filename, MaxAge: 7})
+	defer lj.Close()
+	_, err := lj.Write([]byte("new\n"))
+	require.NoError(err)
+
+	e := testNextEvent(t, events)
+	assert.Equal(RotationDeleted, e.Type)
+	assert.Equal(old, e.Backup)
+	assert.NoFileExists(old)
+}
+
+func testRotationEventsRemove(t *testing.T) {
+	var (
+		require  = require.New(t)
+		lr       = newLumberjackRegistry()
+		events   = make(chan RotationEvent, 10)
+		remove   = lr.OnRotation(func(e RotationEvent) { events <- e })
+		filename = filepath.Join(t.TempDir(), "remove.log")
+		lj       = testLumberjackOpen(t, lr, &lumberjack.Logger{Filename: filename})
+	)
+
+	defer lj.Close()
+	remove()
+	remove()
+
+	_, err := lj.Write([]byte("entry\n"))
+	require.NoError(err)
+	require.NoError(lj.Rotate())
+	testNoEvents(t, events)
+}
+
+func testLumberjackBackups(t *testing.T) {
+	var (
+		assert   = assert.New(t)
+		require  = require.New(t)
+		dir      = t.TempDir()
+		filename = filepath.Join(dir, "app.log")
+		names    = []string{
+			"app-2026-01-02T03-04-05.000.log",
+			"app-2026-01-03T03-04-05.000.log.gz",
+			"app-2026-01-01T03-04-05.000.log",
+			"app-notatimestamp.log",
+			"other-2026-01-02T03-04-05.000.log",
+			"app-2026-01-02T03-04-05.000.txt",
+		}
+	)
+
+	for _, name := range names {
+		require.NoError(os.WriteFile(filepath.Join(dir, name), nil, 0600))
+	}
+
+	backups, err := lumberjackBackups(filename)
+	require.NoError(err)
+
+	var actual []string
+	for _, b := range backups {
+		actual = append(actual, b.name)
+	}
+
+	assert.Equal(
+		"app-2026-01-03T03-04-05.000.log.gz,app-2026-01-02T03-04-05.000.log,app-2026-01-01T03-04-05.000.log",
+		strings.Join(actual, ","),
+	)
+}
+
+func TestRotationEvents(t *testing.T) {
+	t.Run("Rotate", testRotationEventsRotate)
+	t.Run("Size", testRotationEventsSize)
+	t.Run("Compress", testRotationEventsCompress)
+	t.Run("CompressRepeatedly", testRotationEventsCompressRepeatedly)
+	t.Run("Close", testRotationEventsClose)
+	t.Run("Foreign", testRotationEventsForeign)
+	t.Run("MaxBackups", testRotationEventsMaxBackups)
+	t.Run("MaxAge", testRotationEventsMaxAge)
+	t.Run("Remove", testRotationEventsRemove)
+	t.Run("Backups", testLumberjackBackups)
+}
@@ -76,6 +76,9 @@ func (b *Builders) AddFields(more ...FieldBuilder) {
 
 // Named returns a Builder that creates a sublogger with the given name.  Useful
 // to separate functional HTTP areas, such as different handlers, servers, or packages.
+//
+// Loggers built from a sallust.Config honor any sallust.Config.Levels entry that
+// matches the resulting name.
 func Named(name string) Builder {
 	return func(_ *http.Request, l *zap.Logger) *zap.Logger {
 		return l.Named(name)
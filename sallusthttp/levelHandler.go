@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallusthttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/xmidt-org/sallust"
+	"go.uber.org/zap/zapcore"
+)
+
+// Levels is the JSON representation of a logger's levels used by LevelHandler.
+//
+// For a PUT, each field is optional.  An empty string for a per-name level removes
+// that level.  TTL is a time.ParseDuration string that applies to every change in
+// the request.  If TTL is unset, the changes do not revert.
+type Levels struct {
+	// Level is the global level.
+	Level string `json:"level,omitempty"`
+
+	// Levels are the per-name levels, keyed by logger name prefix.
+	Levels map[string]string `json:"levels"`
+
+	// TTL is how long the changes in a PUT last.
+	TTL string `json:"ttl,omitempty"`
+}
+
+// levelsError is the JSON body written when a LevelHandler request fails.
+type levelsError struct {
+	Error string `json:"error"`
+}
+
+// LevelHandler is an http.Handler that reads and changes a logger's levels at runtime.
+// A GET returns the current Levels, while a PUT changes them and returns the result.
+// Other HTTP methods are rejected.
+type LevelHandler struct {
+	// Control is the level handle for the logger, such as the one returned by
+	// sallust.Config.BuildWithLevels.  This field is required.
+	Control *sallust.LevelControl
+}
+
+// levels returns the current state of the LevelControl.
+func (lh LevelHandler) levels() Levels {
+	names := lh.Control.NameLevels()
+	current := Levels{
+		Level:  lh.Control.Level().String(),
+		Levels: make(map[string]string, len(names)),
+	}
+
+	for name, l := range names {
+		current.Levels[name] = l.String()
+	}
+
+	return current
+}
+
+// update parses the requested changes and applies them.  No changes are
+// made if any part of the request is invalid.
+func (lh LevelHandler) update(requested Levels) (err error) {
+	var (
+		ttl   time.Duration
+		level *zapcore.Level
+		names = make(map[string]*zapcore.Level, len(requested.Levels))
+	)
+
+	if len(requested.TTL) > 0 {
+		ttl, err = time.ParseDuration(requested.TTL)
+		if err == nil && ttl < 0 {
+			err = errors.New("ttl cannot be negative")
+		}
+	}
+
+	if err == nil && len(requested.Level) > 0 {
+		level = new(zapcore.Level)
+		err = level.UnmarshalText([]byte(requested.Level))
+	}
+
+	for name, text := range requested.Levels {
+		if err != nil {
+			break
+		}
+
+		if len(text) > 0 {
+			l := new(zapcore.Level)
+			if err = l.UnmarshalText([]byte(text)); err != nil {
+				err = fmt.Errorf("levels[%s]: %w", name, err)
+			}
+
+			names[name] = l
+		} else {
+			names[name] = nil
+		}
+	}
+
+	if err != nil {
+		return
+	}
+
+	if level != nil {
+		lh.Control.SetLevel(*level, ttl)
+	}
+
+	for name, l := range names {
+		if l != nil {
+			lh.Control.SetNameLevel(name, *l, ttl)
+		} else {
+			lh.Control.ClearNameLevel(name, ttl)
+		}
+	}
+
+	return
+}
+
+func (lh LevelHandler) writeJSON(response http.ResponseWriter, status int, v interface{}) {
+	response.Header().Set("Content-Type", "application/json")
+	response.WriteHeader(status)
+	json.NewEncoder(response).Encode(v)
+}
+
+// ServeHTTP handles GET and PUT requests for the logger's levels.
+func (lh LevelHandler) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	switch request.Method {
+	case http.MethodGet:
+		lh.writeJSON(response, http.StatusOK, lh.levels())
+
+	case http.MethodPut:
+		var requested Levels
+		err := json.NewDecoder(request.Body).Decode(&requested)
+		if err == nil {
+			err = lh.update(requested)
+		}
+
+		if err != nil {
+			lh.writeJSON(response, http.StatusBadRequest, levelsError{Error: err.Error()})
+			return
+		}
+
+		lh.writeJSON(response, http.StatusOK, lh.levels())
+
+	default:
+		response.Header().Set("Allow", "GET, PUT")
+		lh.writeJSON(
+			response,
+			http.StatusMethodNotAllowed,
+			levelsError{Error: "Only GET and PUT are supported."},
+		)
+	}
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallusthttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/sallust"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func newTestLevelHandler() LevelHandler {
+	return LevelHandler{
+		Control: sallust.NewLevelControl(
+			zap.NewAtomicLevelAt(zapcore.InfoLevel),
+			map[string]zapcore.Level{
+				"fx": zapcore.WarnLevel,
+			},
+		),
+	}
+}
+
+func serveLevels(lh LevelHandler, method, body string) (*httptest.ResponseRecorder, Levels) {
+	var (
+		response = httptest.NewRecorder()
+		request  = httptest.NewRequest(method, "/levels", strings.NewReader(body))
+		levels   Levels
+	)
+
+	lh.ServeHTTP(response, request)
+	json.Unmarshal(response.Body.Bytes(), &levels)
+	return response, levels
+}
+
+func testLevelHandlerGet(t *testing.T) {
+	var (
+		assert           = assert.New(t)
+		response, levels = serveLevels(newTestLevelHandler(), http.MethodGet, "")
+	)
+
+	assert.Equal(http.StatusOK, response.Code)
+	assert.Equal("application/json", response.Header().Get("Content-Type"))
+	assert.Equal(
+		Levels{
+			Level:  "info",
+			Levels: map[string]string{"fx": "warn"},
+		},
+		levels,
+	)
+}
+
+func testLevelHandlerPut(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		lh      = newTestLevelHandler()
+
+		response, levels = serveLevels(
+			lh,
+			http.MethodPut,
+			`{"level": "error", "levels": {"fx": "", "http.access": "debug"}}`,
+		)
+	)
+
+	require.Equal(http.StatusOK, response.Code)
+	assert.Equal(
+		Levels{
+			Level:  "error",
+			Levels: map[string]string{"http.access": "debug"},
+		},
+		levels,
+	)
+
+	assert.Equal(zapcore.ErrorLevel, lh.Control.Level())
+	assert.Equal(
+		map[string]zapcore.Level{"http.access": zapcore.DebugLevel},
+		lh.Control.NameLevels(),
+	)
+}
+
+func testLevelHandlerPutTTL(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		lh      = newTestLevelHandler()
+
+		response, levels = serveLevels(
+			lh,
+			http.MethodPut,
+			`{"level": "debug", "levels": {"fx": "debug"}, "ttl": "10ms"}`,
+		)
+	)
+
+	require.Equal(http.StatusOK, response.Code)
+	assert.Equal("debug", levels.Level)
+	assert.Equal(map[string]string{"fx": "debug"}, levels.Levels)
+
+	assert.Eventually(
+		func() bool {
+			_, levels := serveLevels(lh, http.MethodGet, "")
+			return levels.Level == "info" && levels.Levels["fx"] == "warn"
+		},
+		time.Second,
+		5*time.Millisecond,
+	)
+}
+
+func testLevelHandlerPutInvalid(t *testing.T) {
+	testData := []string{
+		`this is not JSON`,
+		`{"level": "this is not a valid level"}`,
+		`{"levels": {"fx": "this is not a valid level"}}`,
+		`{"level": "debug", "ttl": "this is not a valid duration"}`,
+		`{"level": "debug", "ttl": "-1s"}`,
+	}
+
+	for _, body := range testData {
+		t.Run(body, func(t *testing.T) {
+			var (
+				assert      = assert.New(t)
+				lh          = newTestLevelHandler()
+				response, _ = serveLevels(lh, http.MethodPut, body)
+			)
+
+			assert.Equal(http.StatusBadRequest, response.Code)
+			assert.Contains(response.Body.String(), `"error"`)
+
+			// nothing should have changed
+			assert.Equal(zapcore.InfoLevel, lh.Control.Level())
+			assert.Equal(map[string]zapcore.Level{"fx": zapcore.WarnLevel}, lh.Control.NameLevels())
+		})
+	}
+}
+
+func testLevelHandlerMethodNotAllowed(t *testing.T) {
+	var (
+		assert      = assert.New(t)
+		response, _ = serveLevels(newTestLevelHandler(), http.MethodPost, "")
+	)
+
+	assert.Equal(http.StatusMethodNotAllowed, response.Code)
+	assert.Equal("GET, PUT", response.Header().Get("Allow"))
+}
+
+func TestLevelHandler(t *testing.T) {
+	t.Run("Get", testLevelHandlerGet)
+	t.Run("Put", testLevelHandlerPut)
+	t.Run("PutTTL", testLevelHandlerPutTTL)
+	t.Run("PutInvalid", testLevelHandlerPutInvalid)
+	t.Run("MethodNotAllowed", testLevelHandlerMethodNotAllowed)
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallusthttp
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xmidt-org/sallust"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// LevelParameter is the MemoryHandler and TailHandler query parameter for the minimum
+	// level of the returned entries, e.g. level=warn.
+	LevelParameter = "level"
+
+	// NameParameter is the MemoryHandler and TailHandler query parameter for a logger name.
+	// Entries from that logger and its descendants are returned.
+	NameParameter = "name"
+
+	// SinceParameter is the MemoryHandler query parameter for the earliest entry time.
+	// The value is either an RFC 3339 time or a duration before now, e.g. since=5m.
+	SinceParameter = "since"
+
+	// UntilParameter is the MemoryHandler query parameter for the latest entry time.
+	// The value has the same format as SinceParameter.
+	UntilParameter = "until"
+
+	// FieldParameter is the MemoryHandler and TailHandler query parameter for a field match,
+	// given as key=value.  This parameter may be repeated, and every field must match.
+	FieldParameter = "field"
+
+	// LimitParameter is the MemoryHandler query parameter for the maximum number of
+	// entries returned.  The most recent entries are preferred.
+	LimitParameter = "limit"
+)
+
+// MemoryHandler is an http.Handler that returns recent log entries from a sallust.Memory
+// sink, such as one configured with a memory://name output path.  Only GET is supported.
+//
+// The response body is the matching entries, oldest first, exactly as they were encoded.
+// The query parameters LevelParameter, NameParameter, SinceParameter, UntilParameter,
+// FieldParameter, and LimitParameter select the entries.  With no parameters, every entry
+// is returned.
+type MemoryHandler struct {
+	// Memory is the sink whose entries are returned, such as the one returned by
+	// sallust.GetMemory.  This field is required.
+	Memory *sallust.Memory
+}
+
+// parseTime parses a SinceParameter or UntilParameter value.
+func parseTime(name, v string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(v); err == nil {
+		return now.Add(-d), nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: invalid time %q", name, v)
+	}
+
+	return t, nil
+}
+
+// parseQuery creates the sallust.MemoryQuery described by a request's query parameters.
+func parseQuery(values url.Values) (mq sallust.MemoryQuery, err error) {
+	now := time.Now()
+	if v := values.Get(LevelParameter); len(v) > 0 {
+		var l zapcore.Level
+		if err = l.UnmarshalText([]byte(v)); err != nil {
+			return mq, fmt.Errorf("%s: %w", LevelParameter, err)
+		}
+
+		mq.Level = l
+	}
+
+	mq.Name = values.Get(NameParameter)
+	if v := values.Get(SinceParameter); err == nil && len(v) > 0 {
+		mq.Since, err = parseTime(SinceParameter, v, now)
+	}
+
+	if v := values.Get(UntilParameter); err == nil && len(v) > 0 {
+		mq.Until, err = parseTime(UntilParameter, v, now)
+	}
+
+	if v := values.Get(LimitParameter); err == nil && len(v) > 0 {
+		mq.Limit, err = strconv.Atoi(v)
+		if err != nil || mq.Limit < 0 {
+			err = fmt.Errorf("%s: invalid limit %q", LimitParameter, v)
+		}
+	}
+
+	for _, f := range values[FieldParameter] {
+		if err != nil {
+			break
+		}
+
+		key, value, ok := strings.Cut(f, "=")
+		if !ok || len(key) == 0 {
+			err = fmt.Errorf("%s: expected key=value, got %q", FieldParameter, f)
+			break
+		}
+
+		if mq.Fields == nil {
+			mq.Fields = make(map[string]string)
+		}
+
+		mq.Fields[key] = value
+	}
+
+	return
+}
+
+// ServeHTTP handles GET requests for recent log entries.
+func (mh MemoryHandler) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		response.Header().Set("Allow", "GET")
+		http.Error(response, "Only GET is supported.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	mq, err := parseQuery(request.URL.Query())
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	response.WriteHeader(http.StatusOK)
+	for _, me := range mh.Memory.Entries(mq) {
+		response.Write(me.Encoded)
+	}
+}
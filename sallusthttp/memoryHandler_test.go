@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallusthttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/sallust"
+	"go.uber.org/zap"
+)
+
+// testMemoryID distinguishes the memory sinks of repeated test runs.
+var testMemoryID atomic.Uint64
+
+// newTestMemoryHandler builds a logger that writes to a new memory sink and
+// writes a few entries.
+func newTestMemoryHandler(t *testing.T, prefix string) MemoryHandler {
+	name := prefix + strconv.FormatUint(testMemoryID.Add(1), 10)
+	l, err := sallust.Config{
+		Level:       "debug",
+		OutputPaths: []string{"memory://" + name},
+		EncoderConfig: sallust.EncoderConfig{
+			DisableDefaultKeys: true,
+			MessageKey:         "msg",
+		},
+	}.Build()
+
+	require.NoError(t, err)
+	l.Named("device").Debug("connected", zap.String("id", "1"))
+	l.Named("device").Error("disconnected", zap.String("id", "2"))
+	l.Named("server").Info("started")
+
+	return MemoryHandler{
+		Memory: sallust.GetMemory(name),
+	}
+}
+
+func serveMemory(mh MemoryHandler, method string, query url.Values) *httptest.ResponseRecorder {
+	var (
+		response = httptest.NewRecorder()
+		request  = httptest.NewRequest(method, "/logs?"+query.Encode(), nil)
+	)
+
+	mh.ServeHTTP(response, request)
+	return response
+}
+
+func testMemoryHandlerGet(t *testing.T) {
+	var (
+		mh = newTestMemoryHandler(t, "handlerGet")
+
+		all = `{"msg":"connected","id":"1"}` + "\n" +
+			`{"msg":"disconnected","id":"2"}` + "\n" +
+			`{"msg":"started"}` + "\n"
+	)
+
+	testData := []struct {
+		description string
+		query       url.Values
+		expected    string
+	}{
+		{
+			description: "All",
+			expected:    all,
+		},
+		{
+			description: "Level",
+			query:       url.Values{LevelParameter: {"info"}},
+			expected:    `{"msg":"disconnected","id":"2"}` + "\n" + `{"msg":"started"}` + "\n",
+		},
+		{
+			description: "Name",
+			query:       url.Values{NameParameter: {"device"}},
+			expected:    `{"msg":"connected","id":"1"}` + "\n" + `{"msg":"disconnected","id":"2"}` + "\n",
+		},
+		{
+			description: "Field",
+			query:       url.Values{FieldParameter: {"id=2"}},
+			expected:    `{"msg":"disconnected","id":"2"}` + "\n",
+		},
+		{
+			description: "Limit",
+			query:       url.Values{LimitParameter: {"1"}},
+			expected:    `{"msg":"started"}` + "\n",
+		},
+		{
+			description: "SinceDuration",
+			query:       url.Values{SinceParameter: {"1h"}},
+			expected:    all,
+		},
+		{
+			description: "SinceTime",
+			query:       url.Values{SinceParameter: {time.Now().Add(time.Hour).Format(time.RFC3339)}},
+			expected:    "",
+		},
+		{
+			description: "Until",
+			query:       url.Values{UntilParameter: {"1h"}},
+			expected:    "",
+		},
+	}
+
+	for _, record := range testData {
+		t.Run(record.description, func(t *testing.T) {
+			response := serveMemory(mh, http.MethodGet, record.query)
+			assert.Equal(t, http.StatusOK, response.Code)
+			assert.Equal(t, "text/plain; charset=utf-8", response.Header().Get("Content-Type"))
+			assert.Equal(t, record.expected, response.Body.String())
+		})
+	}
+}
+
+func testMemoryHandlerInvalid(t *testing.T) {
+	mh := newTestMemoryHandler(t, "handlerInvalid")
+	for _, query := range []url.Values{
+		{LevelParameter: {"nosuch"}},
+		{SinceParameter: {"yesterday"}},
+		{UntilParameter: {"tomorrow"}},
+		{LimitParameter: {"-1"}},
+		{FieldParameter: {"id"}},
+		{FieldParameter: {"=1"}},
+	} {
+		t.Run(query.Encode(), func(t *testing.T) {
+			response := serveMemory(mh, http.MethodGet, query)
+			assert.Equal(t, http.StatusBadRequest, response.Code)
+		})
+	}
+}
+
+func testMemoryHandlerMethodNotAllowed(t *testing.T) {
+	response := serveMemory(newTestMemoryHandler(t, "handlerMethod"), http.MethodPost, nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, response.Code)
+	assert.Equal(t, "GET", response.Header().Get("Allow"))
+}
+
+func TestMemoryHandler(t *testing.T) {
+	t.Run("Get", testMemoryHandlerGet)
+	t.Run("Invalid", testMemoryHandlerInvalid)
+	t.Run("MethodNotAllowed", testMemoryHandlerMethodNotAllowed)
+}
@@ -83,7 +83,8 @@ func RequestInfo(kv []zap.Field, request *http.Request) []zap.Field {
 
 // Header returns a logger func that extracts the value of a header and inserts it as the
 // value of a logging key.  If the header is not present in the request, a blank string
-// is set as the logging key's value.
+// is set as the logging key's value.  Sensitive headers, such as Authorization, can be
+// masked by listing keyName in sallust.Config.Redact.
 func Header(headerName, keyName string) sallust.LoggerFunc {
 	headerName = textproto.CanonicalMIMEHeaderKey(headerName)
 
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallusthttp
+
+import (
+	"bufio"
+	"bytes"
+	"net/http"
+	"strconv"
+
+	"github.com/xmidt-org/sallust"
+)
+
+// DroppedEvent is the Server-Sent Events event type of the notice sent to a TailHandler
+// client when entries were dropped because the client was too slow.  The event's data
+// is the number of entries dropped since the previous notice.
+const DroppedEvent = "dropped"
+
+// TailHandler is an http.Handler that streams log entries from a sallust.Tail, such as one
+// configured with a tail://name output path, as Server-Sent Events.  Only GET is supported.
+//
+// Each entry is sent as a message event whose data is the entry as it was encoded.  The
+// query parameters LevelParameter, NameParameter, and FieldParameter select the entries
+// that are sent.  Entries that arrive faster than the client can read them are dropped, and
+// the client is sent a DroppedEvent before the next entry.
+type TailHandler struct {
+	// Tail is the sink whose entries are streamed, such as the one returned by
+	// sallust.GetTail.  This field is required.
+	Tail *sallust.Tail
+
+	// Buffer is the number of entries held for each client.  If unset,
+	// sallust.DefaultTailBuffer is used.
+	Buffer int
+}
+
+// writeEvent writes a single Server-Sent Event.  Each line of the data is sent as a
+// separate data field, as SSE requires.
+func writeEvent(w *bufio.Writer, event string, data []byte) error {
+	if len(event) > 0 {
+		w.WriteString("event: ")
+		w.WriteString(event)
+		w.WriteByte('\n')
+	}
+
+	for _, line := range bytes.Split(bytes.TrimRight(data, "\r\n"), []byte{'\n'}) {
+		w.WriteString("data: ")
+		w.Write(bytes.TrimRight(line, "\r"))
+		w.WriteByte('\n')
+	}
+
+	w.WriteByte('\n')
+	return w.Flush()
+}
+
+// ServeHTTP streams log entries until the client disconnects.
+func (th TailHandler) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		response.Header().Set("Allow", "GET")
+		http.Error(response, "Only GET is supported.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := response.(http.Flusher)
+	if !ok {
+		http.Error(response, "Streaming is not supported.", http.StatusInternalServerError)
+		return
+	}
+
+	mq, err := parseQuery(request.URL.Query())
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sub := th.Tail.Subscribe(mq, th.Buffer)
+	defer sub.Close()
+
+	response.Header().Set("Content-Type", "text/event-stream")
+	response.Header().Set("Cache-Control", "no-cache")
+	response.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	var (
+		w        = bufio.NewWriter(response)
+		reported uint64
+	)
+
+	for {
+		select {
+		case <-request.Context().Done():
+			return
+
+		case me, ok := <-sub.Entries():
+			if !ok {
+				return
+			}
+
+			if dropped := sub.Dropped(); dropped > reported {
+				err = writeEvent(w, DroppedEvent, []byte(strconv.FormatUint(dropped-reported, 10)))
+				reported = dropped
+			}
+
+			if err == nil {
+				err = writeEvent(w, "", me.Encoded)
+			}
+
+			if err != nil {
+				return
+			}
+
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallusthttp
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xmidt-org/sallust"
+	"go.uber.org/zap"
+)
+
+// newTestTailLogger builds a logger that writes only messages to a named tail sink.
+func newTestTailLogger(t *testing.T, name string) *zap.Logger {
+	l, err := sallust.Config{
+		Level:       "debug",
+		OutputPaths: []string{"tail://" + name},
+		EncoderConfig: sallust.EncoderConfig{
+			DisableDefaultKeys: true,
+			MessageKey:         "msg",
+		},
+	}.Build()
+
+	require.NoError(t, err)
+	return l
+}
+
+// waitForSubscribers waits until a tail has the given number of subscribers.
+func waitForSubscribers(t *testing.T, tail *sallust.Tail, n int) {
+	assert.Eventually(
+		t,
+		func() bool { return tail.Subscribers() == n },
+		time.Second,
+		10*time.Millisecond,
+	)
+}
+
+// readEvent reads a single Server-Sent Event, returning its lines.
+func readEvent(t *testing.T, r *bufio.Reader) (lines []string) {
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		if line == "\n" {
+			return
+		}
+
+		lines = append(lines, strings.TrimSuffix(line, "\n"))
+	}
+}
+
+func testTailHandlerStream(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		l       = newTestTailLogger(t, "handlerStream")
+		th      = TailHandler{Tail: sallust.GetTail("handlerStream")}
+		server  = httptest.NewServer(th)
+	)
+
+	defer server.Close()
+	response, err := http.Get(server.URL + "/tail?level=info&name=device&field=id%3D2")
+	require.NoError(err)
+	defer response.Body.Close()
+
+	assert.Equal(http.StatusOK, response.StatusCode)
+	assert.Equal("text/event-stream", response.Header.Get("Content-Type"))
+	waitForSubscribers(t, th.Tail, 1)
+
+	l.Named("device").Debug("filtered by level", zap.String("id", "2"))
+	l.Named("server").Info("filtered by name", zap.String("id", "2"))
+	l.Named("device").Info("filtered by field", zap.String("id", "1"))
+	l.Named("device").Error("first", zap.String("id", "2"))
+	l.Named("device.client").Warn("second\nline", zap.String("id", "2"))
+
+	r := bufio.NewReader(response.Body)
+	assert.Equal([]string{`data: {"msg":"first","id":"2"}`}, readEvent(t, r))
+	assert.Equal([]string{`data: {"msg":"second\nline","id":"2"}`}, readEvent(t, r))
+
+	// disconnecting the client unsubscribes
+	response.Body.Close()
+	waitForSubscribers(t, th.Tail, 0)
+}
+
+// blockingWriter is an http.ResponseWriter whose writes wait on a gate.
+type blockingWriter struct {
+	*httptest.ResponseRecorder
+	writing chan struct{}
+	gate    chan struct{}
+}
+
+func (bw *blockingWriter) Write(p []byte) (int, error) {
+	bw.writing <- struct{}{}
+	<-bw.gate
+	return bw.ResponseRecorder.Write(p)
+}
+
+func testTailHandlerDropped(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		l      = newTestTailLogger(t, "handlerDropped")
+		th     = TailHandler{Tail: sallust.GetTail("handlerDropped"), Buffer: 1}
+
+		response = &blockingWriter{
+			ResponseRecorder: httptest.NewRecorder(),
+			writing:          make(chan struct{}, 10),
+			gate:             make(chan struct{}),
+		}
+
+		ctx, cancel = context.WithCancel(context.Background())
+		request     = httptest.NewRequest(http.MethodGet, "/tail", nil).WithContext(ctx)
+		done        = make(chan struct{})
+	)
+
+	go func() {
+		defer close(done)
+		th.ServeHTTP(response, request)
+	}()
+
+	waitForSubscribers(t, th.Tail, 1)
+	l.Info("one")
+	<-response.writing
+
+	// the handler is stuck writing the first entry, so only one more is held
+	l.Info("two")
+	l.Info("three")
+	l.Info("four")
+	close(response.gate)
+
+	assert.Eventually(
+		func() bool { return len(response.writing) == 2 },
+		time.Second,
+		10*time.Millisecond,
+	)
+
+	cancel()
+	<-done
+	assert.Equal(
+		`data: {"msg":"one"}`+"\n\n"+
+			"event: dropped\ndata: 2\n\n"+
+			`data: {"msg":"two"}`+"\n\n",
+		response.Body.String(),
+	)
+}
+
+func testTailHandlerInvalid(t *testing.T) {
+	var (
+		th       = TailHandler{Tail: sallust.GetTail("handlerInvalid")}
+		response = httptest.NewRecorder()
+	)
+
+	th.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/tail?level=nosuch", nil))
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+
+	response = httptest.NewRecorder()
+	th.ServeHTTP(response, httptest.NewRequest(http.MethodPost, "/tail", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, response.Code)
+	assert.Equal(t, "GET", response.Header().Get("Allow"))
+	assert.Zero(t, th.Tail.Subscribers())
+}
+
+func TestTailHandler(t *testing.T) {
+	t.Run("Stream", testTailHandlerStream)
+	t.Run("Dropped", testTailHandlerDropped)
+	t.Run("Invalid", testTailHandlerInvalid)
+}
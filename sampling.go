@@ -0,0 +1,291 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"path"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// DefaultSamplingTick is the sampling interval used when a SamplingRule has no Tick.
+	// This is the same interval used for Config.Sampling.
+	DefaultSamplingTick = time.Second
+
+	// SamplingSummaryMessage is the message of the entries written periodically when
+	// Config.SamplingSummary is set.
+	SamplingSummaryMessage = "sampling summary"
+)
+
+// SamplingRule describes how a subset of log entries is sampled.  Within each Tick, the first
+// Initial entries with a given level and message are logged, and after that only every
+// Thereafter-th such entry is logged.  This is the same algorithm used for Config.Sampling.
+//
+// See: https://pkg.go.dev/go.uber.org/zap/zapcore#NewSamplerWithOptions
+type SamplingRule struct {
+	// Level restricts this rule to entries at exactly this level.  If unset, this rule
+	// applies to entries at any level.
+	Level string `json:"level,omitempty" yaml:"level,omitempty"`
+
+	// Message restricts this rule to entries whose message matches this pattern, which may
+	// contain glob wildcards as understood by path.Match.  If unset, this rule applies to
+	// entries with any message.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+
+	// Initial is the number of entries logged at the start of each Tick.
+	Initial int `json:"initial" yaml:"initial"`
+
+	// Thereafter is the sampling rate after Initial entries have been logged.  If zero,
+	// all entries after the Initial ones are dropped until the next Tick.
+	Thereafter int `json:"thereafter" yaml:"thereafter"`
+
+	// Tick is the sampling interval.  If unset, DefaultSamplingTick is used.
+	Tick time.Duration `json:"tick,omitempty" yaml:"tick,omitempty"`
+}
+
+// samplingLevels is the number of levels tracked by a SamplingCounter.
+const samplingLevels = int(zapcore.FatalLevel-zapcore.DebugLevel) + 1
+
+// SamplingCounter counts the log entries kept and dropped by sampling.  Its Hook method can
+// be used as a zap.SamplingConfig.Hook, and a SamplingCounter may also be set as
+// Config.SamplingCounter to count the entries sampled by a logger built from that Config.
+//
+// A SamplingCounter is safe for concurrent use.  The zero value is ready to use.
+type SamplingCounter struct {
+	kept    [samplingLevels]atomic.Uint64
+	dropped [samplingLevels]atomic.Uint64
+}
+
+// levelIndex returns the array index for a level, or -1 if the level is not tracked.
+func levelIndex(l zapcore.Level) int {
+	if l < zapcore.DebugLevel || l > zapcore.FatalLevel {
+		return -1
+	}
+
+	return int(l - zapcore.DebugLevel)
+}
+
+// Hook records a sampling decision.
+func (sc *SamplingCounter) Hook(ent zapcore.Entry, d zapcore.SamplingDecision) {
+	if i := levelIndex(ent.Level); i >= 0 {
+		if d&zapcore.LogDropped != 0 {
+			sc.dropped[i].Add(1)
+		} else {
+			sc.kept[i].Add(1)
+		}
+	}
+}
+
+// Kept returns the number of entries at the given level that were kept by sampling.
+func (sc *SamplingCounter) Kept(l zapcore.Level) uint64 {
+	if i := levelIndex(l); i >= 0 {
+		return sc.kept[i].Load()
+	}
+
+	return 0
+}
+
+// Dropped returns the number of entries at the given level that were dropped by sampling.
+func (sc *SamplingCounter) Dropped(l zapcore.Level) uint64 {
+	if i := levelIndex(l); i >= 0 {
+		return sc.dropped[i].Load()
+	}
+
+	return 0
+}
+
+// Totals returns the number of entries kept and dropped by sampling at all levels.
+func (sc *SamplingCounter) Totals() (kept, dropped uint64) {
+	for i := 0; i < samplingLevels; i++ {
+		kept += sc.kept[i].Load()
+		dropped += sc.dropped[i].Load()
+	}
+
+	return
+}
+
+// samplingRule is a parsed SamplingRule together with its sampler.
+type samplingRule struct {
+	level   zapcore.Level
+	any     bool
+	message string
+	core    zapcore.Core
+}
+
+func (sr samplingRule) matches(ent zapcore.Entry) bool {
+	if !sr.any && ent.Level != sr.level {
+		return false
+	}
+
+	if len(sr.message) > 0 {
+		ok, _ := path.Match(sr.message, ent.Message)
+		return ok
+	}
+
+	return true
+}
+
+// ruleSampler dispatches each entry to the sampler for the first rule that matches it.
+// Entries that match no rule go to the fallback, which may itself be a sampler.
+type ruleSampler struct {
+	rules    []samplingRule
+	fallback zapcore.Core
+}
+
+var _ zapcore.Core = ruleSampler{}
+
+func (rs ruleSampler) Enabled(l zapcore.Level) bool {
+	return rs.fallback.Enabled(l)
+}
+
+// With applies the fields to each sampler.  The samplers created by zapcore share their
+// counts with the samplers derived from them, so sampling is unaffected.
+func (rs ruleSampler) With(fields []zapcore.Field) zapcore.Core {
+	derived := ruleSampler{
+		rules:    make([]samplingRule, len(rs.rules)),
+		fallback: rs.fallback.With(fields),
+	}
+
+	for i, sr := range rs.rules {
+		sr.core = sr.core.With(fields)
+		derived.rules[i] = sr
+	}
+
+	return derived
+}
+
+func (rs ruleSampler) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	for _, sr := range rs.rules {
+		if sr.matches(ent) {
+			return sr.core.Check(ent, ce)
+		}
+	}
+
+	return rs.fallback.Check(ent, ce)
+}
+
+func (rs ruleSampler) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return rs.fallback.Write(ent, fields)
+}
+
+func (rs ruleSampler) Sync() error {
+	return rs.fallback.Sync()
+}
+
+// samplingHook combines a SamplingCounter with an optional user-supplied hook.
+func samplingHook(counter *SamplingCounter, hook func(zapcore.Entry, zapcore.SamplingDecision)) zapcore.SamplerOption {
+	return zapcore.SamplerHook(func(ent zapcore.Entry, d zapcore.SamplingDecision) {
+		counter.Hook(ent, d)
+		if hook != nil {
+			hook(ent, d)
+		}
+	})
+}
+
+// newSampler applies the sampling described by this Config to a core.  The zap.Config must
+// be the one produced by NewZapConfig.  If a summary goroutine is started, the returned
+// function stops it.  The returned function is idempotent.
+func (c Config) newSampler(zc zap.Config, core zapcore.Core) (zapcore.Core, func()) {
+	var (
+		counter = c.SamplingCounter
+		sampled = core
+	)
+
+	if counter == nil {
+		counter = new(SamplingCounter)
+	}
+
+	if s := zc.Sampling; s != nil {
+		sampled = zapcore.NewSamplerWithOptions(
+			core, DefaultSamplingTick, s.Initial, s.Thereafter, samplingHook(counter, s.Hook),
+		)
+	}
+
+	if len(c.SamplingRules) > 0 {
+		rs := ruleSampler{
+			rules:    make([]samplingRule, 0, len(c.SamplingRules)),
+			fallback: sampled,
+		}
+
+		for _, r := range c.SamplingRules {
+			sr := samplingRule{
+				any:     len(r.Level) == 0,
+				message: r.Message,
+			}
+
+			if !sr.any {
+				// the Config has already been validated
+				sr.level, _ = zapcore.ParseLevel(r.Level)
+			}
+
+			tick := r.Tick
+			if tick <= 0 {
+				tick = DefaultSamplingTick
+			}
+
+			sr.core = zapcore.NewSamplerWithOptions(core, tick, r.Initial, r.Thereafter, samplingHook(counter, nil))
+			rs.rules = append(rs.rules, sr)
+		}
+
+		sampled = rs
+	}
+
+	if c.SamplingSummary <= 0 || sampled == core {
+		return sampled, func() {}
+	}
+
+	var (
+		done          = make(chan struct{})
+		kept, dropped = counter.Totals()
+	)
+
+	var once sync.Once
+	go summarizeSampling(core, counter, kept, dropped, c.SamplingSummary, done)
+	return sampled, func() {
+		once.Do(func() { close(done) })
+	}
+}
+
+// summarizeSampling periodically writes an entry with the number of entries kept and dropped
+// by sampling since the previous summary, starting from the given totals.  Nothing is written for
+// an interval with no sampling activity.  The summary is written to the unsampled core, bypassing
+// its level checks, so that it is never itself dropped.
+func summarizeSampling(core zapcore.Core, counter *SamplingCounter, lastKept, lastDropped uint64, interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+
+		case <-ticker.C:
+			kept, dropped := counter.Totals()
+			if kept == lastKept && dropped == lastDropped {
+				continue
+			}
+
+			ent := zapcore.Entry{
+				Level:   zapcore.InfoLevel,
+				Time:    time.Now(),
+				Message: SamplingSummaryMessage,
+			}
+
+			// written directly, since an info entry would not pass the level checks of a
+			// logger at warn or above
+			_ = core.Write(ent, []zap.Field{
+				zap.Uint64("kept", kept-lastKept),
+				zap.Uint64("dropped", dropped-lastDropped),
+				zap.Duration("interval", interval),
+			})
+
+			lastKept, lastDropped = kept, dropped
+		}
+	}
+}
@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func testSamplingCounter(t *testing.T) {
+	var (
+		assert = assert.New(t)
+		sc     SamplingCounter
+	)
+
+	sc.Hook(zapcore.Entry{Level: zapcore.InfoLevel}, zapcore.LogSampled)
+	sc.Hook(zapcore.Entry{Level: zapcore.InfoLevel}, zapcore.LogDropped)
+	sc.Hook(zapcore.Entry{Level: zapcore.InfoLevel}, zapcore.LogDropped)
+	sc.Hook(zapcore.Entry{Level: zapcore.ErrorLevel}, zapcore.LogSampled)
+	sc.Hook(zapcore.Entry{Level: zapcore.InvalidLevel}, zapcore.LogDropped)
+
+	assert.Equal(uint64(1), sc.Kept(zapcore.InfoLevel))
+	assert.Equal(uint64(2), sc.Dropped(zapcore.InfoLevel))
+	assert.Equal(uint64(1), sc.Kept(zapcore.ErrorLevel))
+	assert.Zero(sc.Dropped(zapcore.ErrorLevel))
+	assert.Zero(sc.Dropped(zapcore.InvalidLevel))
+
+	kept, dropped := sc.Totals()
+	assert.Equal(uint64(2), kept)
+	assert.Equal(uint64(2), dropped)
+}
+
+func testSamplingRules(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		file    = filepath.Join(t.TempDir(), "sampled.json")
+		counter = new(SamplingCounter)
+		hooked  int
+	)
+
+	l, err := Config{
+		Level:       "debug",
+		OutputPaths: []string{file},
+		Sampling: &zap.SamplingConfig{
+			Initial:    2,
+			Thereafter: 1000,
+			Hook: func(zapcore.Entry, zapcore.SamplingDecision) {
+				hooked++
+			},
+		},
+		SamplingRules: []SamplingRule{
+			// never sample errors
+			{Level: "error", Initial: 1000000},
+			{Message: "cache *", Initial: 1, Tick: time.Hour},
+			{Level: "debug", Initial: 0, Thereafter: 0},
+		},
+		SamplingCounter: counter,
+	}.Build()
+
+	require.NoError(err)
+	for i := 0; i < 10; i++ {
+		l.Error("error message")
+		l.Info("cache miss")
+		l.Info("info message")
+		l.Debug("debug message")
+	}
+
+	require.NoError(l.Sync())
+	contents, err := os.ReadFile(file)
+	require.NoError(err)
+
+	assert.Equal(10, strings.Count(string(contents), "error message"))
+	assert.Equal(1, strings.Count(string(contents), "cache miss"))
+	assert.Equal(2, strings.Count(string(contents), "info message"))
+	assert.Zero(strings.Count(string(contents), "debug message"))
+
+	assert.Equal(10, hooked)
+	assert.Equal(uint64(10), counter.Kept(zapcore.ErrorLevel))
+	assert.Equal(uint64(3), counter.Kept(zapcore.InfoLevel))
+	assert.Equal(uint64(17), counter.Dropped(zapcore.InfoLevel))
+	assert.Equal(uint64(10), counter.Dropped(zapcore.DebugLevel))
+}
+
+func testSamplingSummary(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		file    = filepath.Join(t.TempDir(), "summary.json")
+	)
+
+	l, r, err := Config{
+		OutputPaths: []string{file},
+		Sampling: &zap.SamplingConfig{
+			Initial:    1,
+			Thereafter: 1000,
+		},
+		SamplingSummary: 20 * time.Millisecond,
+	}.BuildReloadable()
+
+	require.NoError(err)
+	for i := 0; i < 5; i++ {
+		l.Info("repeated")
+	}
+
+	assert.Eventually(
+		func() bool {
+			contents, err := os.ReadFile(file)
+			return err == nil && strings.Contains(string(contents), SamplingSummaryMessage)
+		},
+		time.Second,
+		10*time.Millisecond,
+	)
+
+	contents, err := os.ReadFile(file)
+	require.NoError(err)
+	assert.Contains(string(contents), `"kept":1,"dropped":4`)
+
+	// reloading stops the summary for the old configuration
+	require.NoError(r.Reload(Config{OutputPaths: []string{file}}))
+}
+
+func testSamplingSummaryLevel(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		file    = filepath.Join(t.TempDir(), "summary.json")
+	)
+
+	l, _, closer, err := Config{
+		Level:       "warn",
+		OutputPaths: []string{file},
+		Sampling: &zap.SamplingConfig{
+			Initial:    1,
+			Thereafter: 1000,
+		},
+		SamplingSummary: 20 * time.Millisecond,
+	}.BuildWithCloser()
+
+	require.NoError(err)
+	defer closer()
+	for i := 0; i < 5; i++ {
+		l.Warn("repeated")
+	}
+
+	// the info summary is written even though the logger is at warn
+	assert.Eventually(
+		func() bool {
+			contents, err := os.ReadFile(file)
+			return err == nil && strings.Contains(string(contents), `"kept":1,"dropped":4`)
+		},
+		time.Second,
+		10*time.Millisecond,
+	)
+}
+
+func testSamplingSummaryClose(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		file    = filepath.Join(t.TempDir(), "summary.json")
+		counter = new(SamplingCounter)
+	)
+
+	l, _, closer, err := Config{
+		OutputPaths: []string{file},
+		Sampling: &zap.SamplingConfig{
+			Initial:    1,
+			Thereafter: 1000,
+		},
+		SamplingSummary: 20 * time.Millisecond,
+		SamplingCounter: counter,
+	}.BuildWithCloser()
+
+	require.NoError(err)
+	l.Info("repeated")
+	l.Info("repeated")
+
+	assert.Eventually(
+		func() bool {
+			contents, err := os.ReadFile(file)
+			return err == nil && strings.Contains(string(contents), SamplingSummaryMessage)
+		},
+		time.Second,
+		10*time.Millisecond,
+	)
+
+	closer()
+	closer()
+	before, err := os.ReadFile(file)
+	require.NoError(err)
+
+	// once closed, sampling activity produces no more summaries
+	counter.Hook(zapcore.Entry{Level: zapcore.InfoLevel}, zapcore.LogDropped)
+	time.Sleep(100 * time.Millisecond)
+
+	after, err := os.ReadFile(file)
+	require.NoError(err)
+	assert.Equal(string(before), string(after))
+}
+
+func testSamplingValidate(t *testing.T) {
+	assert.Equal(
+		t,
+		[]string{
+			"samplingRules[0].level",
+			"samplingRules[0].message",
+			"samplingRules[1].initial",
+			"samplingRules[1].thereafter",
+			"samplingRules[1].tick",
+			"samplingSummary",
+		},
+		validationPaths(t, Config{
+			SamplingRules: []SamplingRule{
+				{Level: "this is not a valid level", Message: "[unterminated"},
+				{Initial: -1, Thereafter: -1, Tick: -time.Second},
+			},
+			SamplingSummary: -time.Second,
+		}.Validate()),
+	)
+}
+
+func TestSampling(t *testing.T) {
+	t.Run("Counter", testSamplingCounter)
+	t.Run("Rules", testSamplingRules)
+	t.Run("Summary", testSamplingSummary)
+	t.Run("SummaryLevel", testSamplingSummaryLevel)
+	t.Run("SummaryClose", testSamplingSummaryClose)
+	t.Run("Validate", testSamplingValidate)
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ScheduleHourly is the Rotation.Schedule value that rotates at the top of every hour.
+	ScheduleHourly = "hourly"
+
+	// ScheduleDaily is the Rotation.Schedule value that rotates at midnight.
+	ScheduleDaily = "daily"
+
+	// maxScheduleInterval is the longest interval a rotation schedule may have.  Intervals
+	// restart at each midnight, so longer intervals would never be reached.
+	maxScheduleInterval = 24 * time.Hour
+)
+
+// ParseSchedule parses a rotation schedule, returning the interval between rotations.
+// The schedule is either ScheduleHourly, ScheduleDaily, or a time.ParseDuration string
+// such as "6h" or "15m".  The interval must be positive and no more than a day.
+//
+// Rotations happen on the boundaries of the interval counted from each midnight, in the
+// same way as a cron expression.  For example, "6h" rotates at 00:00, 06:00, 12:00, and
+// 18:00, while "7h" rotates at 00:00, 07:00, 14:00, and 21:00.
+func ParseSchedule(s string) (time.Duration, error) {
+	switch strings.ToLower(s) {
+	case ScheduleHourly:
+		return time.Hour, nil
+
+	case ScheduleDaily:
+		return maxScheduleInterval, nil
+	}
+
+	interval, err := time.ParseDuration(s)
+	if err != nil || interval <= 0 || interval > maxScheduleInterval {
+		return 0, fmt.Errorf("Invalid rotation schedule [%s]", s) // nolint:staticcheck
+	}
+
+	return interval, nil
+}
+
+// rotationSchedule decides when a Lumberjack sink rotates its file based on time.
+type rotationSchedule struct {
+	interval time.Duration
+	location *time.Location
+	now      func() time.Time
+
+	lock sync.Mutex
+	next time.Time
+}
+
+// newRotationSchedule creates a rotationSchedule whose boundaries are computed
+// in the given location.
+func newRotationSchedule(interval time.Duration, location *time.Location) *rotationSchedule {
+	return &rotationSchedule{
+		interval: interval,
+		location: location,
+		now:      time.Now,
+	}
+}
+
+// boundary returns the first rotation time after t.  Boundaries are wall clock times, so
+// that a day with a daylight saving transition still rotates at the same times of day as
+// any other.  A boundary skipped by the clocks going forward falls at the transition, and
+// the hour repeated by the clocks going back has no boundaries of its own.
+func (rs *rotationSchedule) boundary(t time.Time) time.Time {
+	t = t.In(rs.location)
+	var (
+		year, month, day = t.Date()
+		hour, min, sec   = t.Clock()
+		elapsed          = time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute +
+			time.Duration(sec)*time.Second + time.Duration(t.Nanosecond())
+	)
+
+	for offset := (elapsed/rs.interval + 1) * rs.interval; offset < maxScheduleInterval; offset += rs.interval {
+		// time.Date normalizes the nanoseconds into the wall clock fields
+		if next := time.Date(year, month, day, 0, 0, 0, int(offset), rs.location); next.After(t) {
+			return next
+		}
+	}
+
+	return time.Date(year, month, day+1, 0, 0, 0, 0, rs.location)
+}
+
+// check calls rotate if a boundary has passed since the last call.  The first call
+// measures from the modification time of the file, so that a file left over from an
+// earlier period is rotated before it is written to again.
+func (rs *rotationSchedule) check(filename string, rotate func() error) error {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	now := rs.now()
+	if rs.next.IsZero() {
+		rs.next = rs.boundary(now)
+		if fi, err := os.Stat(filename); err == nil {
+			rs.next = rs.boundary(fi.ModTime())
+		}
+	}
+
+	if now.Before(rs.next) {
+		return nil
+	}
+
+	rs.next = rs.boundary(now)
+	return rotate()
+}
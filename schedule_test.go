@@ -0,0 +1,271 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testParseScheduleValid(t *testing.T) {
+	testData := []struct {
+		schedule string
+		expected time.Duration
+	}{
+		{ScheduleHourly, time.Hour},
+		{"Daily", 24 * time.Hour},
+		{"6h", 6 * time.Hour},
+		{"15m", 15 * time.Minute},
+		{"24h", 24 * time.Hour},
+	}
+
+	for _, record := range testData {
+		t.Run(record.schedule, func(t *testing.T) {
+			interval, err := ParseSchedule(record.schedule)
+			assert.NoError(t, err)
+			assert.Equal(t, record.expected, interval)
+		})
+	}
+}
+
+func testParseScheduleInvalid(t *testing.T) {
+	for _, schedule := range []string{"", "weekly", "0s", "-1h", "25h"} {
+		t.Run(schedule, func(t *testing.T) {
+			interval, err := ParseSchedule(schedule)
+			assert.Error(t, err)
+			assert.Zero(t, interval)
+		})
+	}
+}
+
+func TestParseSchedule(t *testing.T) {
+	t.Run("Valid", testParseScheduleValid)
+	t.Run("Invalid", testParseScheduleInvalid)
+}
+
+func testRotationScheduleBoundary(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	testData := []struct {
+		description string
+		interval    time.Duration
+		location    *time.Location
+		t           time.Time
+		expected    time.Time
+	}{
+		{
+			description: "Hourly",
+			interval:    time.Hour,
+			location:    time.UTC,
+			t:           time.Date(2026, 3, 14, 9, 26, 53, 0, time.UTC),
+			expected:    time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			description: "Daily",
+			interval:    24 * time.Hour,
+			location:    time.UTC,
+			t:           time.Date(2026, 3, 14, 9, 26, 53, 0, time.UTC),
+			expected:    time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			description: "OnBoundary",
+			interval:    6 * time.Hour,
+			location:    time.UTC,
+			t:           time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC),
+			expected:    time.Date(2026, 3, 14, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			description: "Uneven",
+			interval:    7 * time.Hour,
+			location:    time.UTC,
+			t:           time.Date(2026, 3, 14, 22, 0, 0, 0, time.UTC),
+			expected:    time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			description: "Location",
+			interval:    24 * time.Hour,
+			location:    est,
+			t:           time.Date(2026, 3, 14, 3, 0, 0, 0, time.UTC),
+			expected:    time.Date(2026, 3, 14, 0, 0, 0, 0, est),
+		},
+	}
+
+	for _, record := range testData {
+		t.Run(record.description, func(t *testing.T) {
+			rs := newRotationSchedule(record.interval, record.location)
+			assert.True(t, record.expected.Equal(rs.boundary(record.t)), rs.boundary(record.t))
+		})
+	}
+}
+
+func testRotationScheduleDST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// 2026-03-08 and 2026-11-01 are the days daylight saving time starts and ends
+	testData := []struct {
+		description string
+		interval    time.Duration
+		t           time.Time
+		expected    time.Time
+	}{
+		{
+			description: "DailyFallBack",
+			interval:    24 * time.Hour,
+			t:           time.Date(2026, 11, 1, 22, 30, 0, 0, newYork),
+			expected:    time.Date(2026, 11, 2, 0, 0, 0, 0, newYork),
+		},
+		{
+			description: "DailyFallBackMorning",
+			interval:    24 * time.Hour,
+			t:           time.Date(2026, 11, 1, 0, 30, 0, 0, newYork),
+			expected:    time.Date(2026, 11, 2, 0, 0, 0, 0, newYork),
+		},
+		{
+			description: "DailySpringForward",
+			interval:    24 * time.Hour,
+			t:           time.Date(2026, 3, 8, 22, 30, 0, 0, newYork),
+			expected:    time.Date(2026, 3, 9, 0, 0, 0, 0, newYork),
+		},
+		{
+			description: "SixHoursFallBack",
+			interval:    6 * time.Hour,
+			t:           time.Date(2026, 11, 1, 0, 30, 0, 0, newYork),
+			expected:    time.Date(2026, 11, 1, 6, 0, 0, 0, newYork),
+		},
+		{
+			description: "SixHoursFallBackEvening",
+			interval:    6 * time.Hour,
+			t:           time.Date(2026, 11, 1, 12, 0, 0, 0, newYork),
+			expected:    time.Date(2026, 11, 1, 18, 0, 0, 0, newYork),
+		},
+		{
+			description: "SixHoursSpringForward",
+			interval:    6 * time.Hour,
+			t:           time.Date(2026, 3, 8, 0, 30, 0, 0, newYork),
+			expected:    time.Date(2026, 3, 8, 6, 0, 0, 0, newYork),
+		},
+		{
+			description: "HourlyRepeatedHour",
+			interval:    time.Hour,
+			t:           time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC), // 01:30 EDT
+			expected:    time.Date(2026, 11, 1, 7, 0, 0, 0, time.UTC),  // 02:00 EST
+		},
+		{
+			description: "HourlySkippedHour",
+			interval:    time.Hour,
+			t:           time.Date(2026, 3, 8, 6, 30, 0, 0, time.UTC), // 01:30 EST
+			expected:    time.Date(2026, 3, 8, 7, 0, 0, 0, time.UTC),  // 03:00 EDT
+		},
+		{
+			description: "HourlyAfterSkippedHour",
+			interval:    time.Hour,
+			t:           time.Date(2026, 3, 8, 7, 0, 0, 0, time.UTC), // 03:00 EDT
+			expected:    time.Date(2026, 3, 8, 8, 0, 0, 0, time.UTC), // 04:00 EDT
+		},
+	}
+
+	for _, record := range testData {
+		t.Run(record.description, func(t *testing.T) {
+			rs := newRotationSchedule(record.interval, newYork)
+			assert.True(t, record.expected.Equal(rs.boundary(record.t)), rs.boundary(record.t))
+		})
+	}
+
+	// every boundary of a daily schedule is a midnight, including across both transitions
+	rs := newRotationSchedule(24*time.Hour, newYork)
+	for next := time.Date(2026, 1, 1, 0, 0, 0, 0, newYork); next.Year() == 2026; {
+		next = rs.boundary(next)
+		assert.Equal(t, "00:00", next.Format("15:04"))
+	}
+}
+
+// testScheduledSink creates a Lumberjack sink with a schedule and a fake clock.
+func testScheduledSink(t *testing.T, filename, schedule string, now *time.Time) Lumberjack {
+	sink, err := NewLumberjackSink(&url.URL{
+		Scheme:   LumberjackScheme,
+		Path:     filename,
+		RawQuery: url.Values{ScheduleParameter: {schedule}}.Encode(),
+	})
+
+	require.NoError(t, err)
+	lj := sink.(Lumberjack)
+	lj.ref.file.schedule.now = func() time.Time { return *now }
+	t.Cleanup(func() { lj.Close() })
+	return lj
+}
+
+// testBackups returns the backup files lumberjack has created next to a file.
+func testBackups(t *testing.T, filename string) []string {
+	backups, err := filepath.Glob(filename[:len(filename)-len(filepath.Ext(filename))] + "-*")
+	require.NoError(t, err)
+	return backups
+}
+
+func testRotationScheduleRotate(t *testing.T) {
+	var (
+		assert   = assert.New(t)
+		require  = require.New(t)
+		filename = filepath.Join(t.TempDir(), "test.log")
+		now      = time.Now().UTC()
+		lj       = testScheduledSink(t, filename, ScheduleHourly, &now)
+	)
+
+	_, err := lj.Write([]byte("first\n"))
+	require.NoError(err)
+	_, err = lj.Write([]byte("second\n"))
+	require.NoError(err)
+	assert.Empty(testBackups(t, filename))
+
+	now = now.Add(time.Hour)
+	_, err = lj.Write([]byte("third\n"))
+	require.NoError(err)
+
+	backups := testBackups(t, filename)
+	require.Len(backups, 1)
+
+	contents, err := os.ReadFile(backups[0])
+	require.NoError(err)
+	assert.Equal("first\nsecond\n", string(contents))
+
+	contents, err = os.ReadFile(filename)
+	require.NoError(err)
+	assert.Equal("third\n", string(contents))
+}
+
+func testRotationScheduleStale(t *testing.T) {
+	var (
+		assert   = assert.New(t)
+		require  = require.New(t)
+		filename = filepath.Join(t.TempDir(), "test.log")
+		now      = time.Now().UTC()
+	)
+
+	// a file from yesterday is rotated before the first write
+	require.NoError(os.WriteFile(filename, []byte("yesterday\n"), 0600))
+	yesterday := now.AddDate(0, 0, -1)
+	require.NoError(os.Chtimes(filename, yesterday, yesterday))
+
+	lj := testScheduledSink(t, filename, ScheduleDaily, &now)
+	_, err := lj.Write([]byte("today\n"))
+	require.NoError(err)
+	assert.Len(testBackups(t, filename), 1)
+
+	contents, err := os.ReadFile(filename)
+	require.NoError(err)
+	assert.Equal("today\n", string(contents))
+}
+
+func TestRotationSchedule(t *testing.T) {
+	t.Run("Boundary", testRotationScheduleBoundary)
+	t.Run("DST", testRotationScheduleDST)
+	t.Run("Rotate", testRotationScheduleRotate)
+	t.Run("Stale", testRotationScheduleStale)
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+var (
+	sinkSchemesLock sync.RWMutex
+
+	// sinkSchemes are the URL schemes known to have registered sinks.  zap registers
+	// the file scheme itself.
+	sinkSchemes = map[string]bool{
+		"file":           true,
+		LumberjackScheme: true,
+	}
+
+	// sinkErrors holds the errors from registering this package's sinks with zap, keyed
+	// by scheme.  Another package may already have registered the same scheme.
+	sinkErrors = map[string]error{}
+)
+
+// registerSink registers one of this package's sink factories with zap.  A failure is
+// recorded rather than reported, so that importing this package never fails.  Build and
+// Validate report the failure for any output path that uses the scheme.
+func registerSink(scheme string, factory func(*url.URL) (zap.Sink, error)) {
+	err := zap.RegisterSink(scheme, factory)
+
+	sinkSchemesLock.Lock()
+	defer sinkSchemesLock.Unlock()
+	sinkSchemes[strings.ToLower(scheme)] = true
+	if err != nil {
+		sinkErrors[strings.ToLower(scheme)] = fmt.Errorf("cannot register sink: %w", err)
+	}
+}
+
+// RegisterSink registers a sink factory with zap.RegisterSink and declares its scheme
+// to Config.Validate.  If zap refuses the factory, the scheme is not declared.
+func RegisterSink(scheme string, factory func(*url.URL) (zap.Sink, error)) error {
+	err := zap.RegisterSink(scheme, factory)
+	if err == nil {
+		DeclareSinkScheme(scheme)
+	}
+
+	return err
+}
+
+// DeclareSinkScheme declares URL schemes whose sinks were registered directly with
+// zap.RegisterSink, so that Config.Validate accepts output paths that use them.
+func DeclareSinkScheme(schemes ...string) {
+	sinkSchemesLock.Lock()
+	defer sinkSchemesLock.Unlock()
+	for _, scheme := range schemes {
+		sinkSchemes[strings.ToLower(scheme)] = true
+	}
+}
+
+// isSinkScheme tests if a URL scheme has a known registered sink.
+func isSinkScheme(scheme string) bool {
+	sinkSchemesLock.RLock()
+	defer sinkSchemesLock.RUnlock()
+	return sinkSchemes[strings.ToLower(scheme)]
+}
+
+// sinkError returns the error from registering this package's sink for the given
+// URL scheme, if there was one.
+func sinkError(scheme string) error {
+	sinkSchemesLock.RLock()
+	defer sinkSchemesLock.RUnlock()
+	return sinkErrors[strings.ToLower(scheme)]
+}
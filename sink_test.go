@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func testRegisterSinkTaken(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		factory = func(*url.URL) (zap.Sink, error) { return nil, nil }
+	)
+
+	// another package has already registered the scheme
+	require.NoError(zap.RegisterSink("sallust-taken", factory))
+	assert.NotPanics(func() {
+		registerSink("sallust-taken", factory)
+	})
+
+	assert.Error(sinkError("sallust-taken"))
+	assert.Error(sinkError("SALLUST-TAKEN"))
+	assert.NoError(sinkError(MemoryScheme))
+
+	c := Config{OutputPaths: []string{"sallust-taken://sink"}}
+	assert.Equal([]string{"outputPaths[0]"}, validationPaths(t, c.Validate()))
+
+	l, err := c.Build()
+	assert.Nil(l)
+	assert.Error(err)
+}
+
+func testRegisterSinkPublic(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		factory = func(*url.URL) (zap.Sink, error) { return nil, nil }
+		c       = Config{OutputPaths: []string{"sallust-public://sink"}}
+	)
+
+	assert.Error(c.Validate())
+	assert.NoError(RegisterSink("sallust-public", factory))
+	assert.NoError(c.Validate())
+
+	// zap refuses a second factory for the same scheme
+	assert.Error(RegisterSink("sallust-public", factory))
+}
+
+func TestRegisterSink(t *testing.T) {
+	t.Run("Taken", testRegisterSinkTaken)
+	t.Run("Public", testRegisterSinkPublic)
+}
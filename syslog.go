@@ -0,0 +1,492 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// SyslogScheme is the URL scheme for sending log entries to a syslog server over UDP,
+	// e.g. syslog://logs.example.com:514.  If the host is omitted, localhost is used.  If the
+	// port is omitted, DefaultSyslogPort is used.
+	SyslogScheme = "syslog"
+
+	// SyslogTCPScheme is the URL scheme for sending log entries to a syslog server over TCP,
+	// e.g. syslog+tcp://logs.example.com:514.
+	SyslogTCPScheme = "syslog+tcp"
+
+	// SyslogUnixScheme is the URL scheme for sending log entries to a local syslog daemon over
+	// a unix domain socket, e.g. syslog+unix:///dev/log.  If the path is omitted, DefaultSyslogSocket
+	// is used.
+	SyslogUnixScheme = "syslog+unix"
+
+	// DefaultSyslogPort is the port used when a syslog URL has none.
+	DefaultSyslogPort = "514"
+
+	// DefaultSyslogSocket is the unix domain socket used when a syslog+unix URL has no path.
+	DefaultSyslogSocket = "/dev/log"
+
+	// FacilityParameter is the syslog URL parameter for the facility, either by name such as
+	// "local0" or by number.  If unset, "user" is used.
+	FacilityParameter = "facility"
+
+	// AppNameParameter is the syslog URL parameter for the application name.  If unset, the
+	// base name of the executable is used.
+	AppNameParameter = "app"
+
+	// HostnameParameter is the syslog URL parameter for the hostname.  If unset, os.Hostname
+	// is used.
+	HostnameParameter = "hostname"
+
+	// FormatParameter is the syslog URL parameter for the message format, either SyslogRFC5424
+	// or SyslogRFC3164.  If unset, SyslogRFC5424 is used.
+	FormatParameter = "format"
+
+	// SyslogRFC5424 is the FormatParameter value for RFC 5424 messages.
+	SyslogRFC5424 = "rfc5424"
+
+	// SyslogRFC3164 is the FormatParameter value for the older BSD format described in RFC 3164.
+	SyslogRFC3164 = "rfc3164"
+)
+
+// syslogFacilities are the facility names accepted by FacilityParameter.
+var syslogFacilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+func init() {
+	registerSink(SyslogScheme, NewSyslogSink)
+	registerSink(SyslogTCPScheme, NewSyslogSink)
+	registerSink(SyslogUnixScheme, NewSyslogSink)
+}
+
+// isSyslogScheme tests if a URL scheme is one of the syslog schemes.
+func isSyslogScheme(scheme string) bool {
+	switch strings.ToLower(scheme) {
+	case SyslogScheme, SyslogTCPScheme, SyslogUnixScheme:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// SyslogSeverity returns the syslog severity for a zap level.  Levels above FatalLevel
+// map to emergency, and levels below DebugLevel map to debug.
+func SyslogSeverity(l zapcore.Level) int {
+	switch {
+	case l >= zapcore.FatalLevel:
+		return 0 // emergency
+
+	case l == zapcore.PanicLevel:
+		return 1 // alert
+
+	case l == zapcore.DPanicLevel:
+		return 2 // critical
+
+	case l == zapcore.ErrorLevel:
+		return 3 // error
+
+	case l == zapcore.WarnLevel:
+		return 4 // warning
+
+	case l == zapcore.InfoLevel:
+		return 6 // informational
+
+	default:
+		return 7 // debug
+	}
+}
+
+// Syslog is a zap.Sink that sends each write to a syslog server as a single message.  The
+// connection is made on the first write, and is remade if a write fails.
+//
+// Each connection attempt and each write is bounded by the write timeout.  After a failed
+// connection attempt, writes fail immediately until the backoff delay has passed, so that
+// an unreachable server does not hold up logging.  The delay doubles after each further
+// failure, up to the maximum backoff.
+//
+// When a Config's output paths include syslog URLs, each message is sent with the severity
+// of its log entry.  When a Syslog is used through zap.Open, which does not supply levels,
+// messages are sent with informational severity.
+//
+// A Syslog is safe for concurrent writes.  No additional synchronization is required.
+type Syslog struct {
+	network  string
+	address  string
+	format   string
+	facility int
+	hostname string
+	app      string
+	pid      int
+
+	writeTimeout time.Duration
+	minBackoff   time.Duration
+	maxBackoff   time.Duration
+
+	lock    sync.Mutex
+	conn    net.Conn
+	stream  bool
+	backoff time.Duration
+	retryAt time.Time
+	dialErr error
+}
+
+var _ zap.Sink = (*Syslog)(nil)
+
+// NewSyslogSink creates a zap.Sink for a syslog URL.  The URL's query may contain
+// FacilityParameter, AppNameParameter, HostnameParameter, FormatParameter, and the
+// WriteTimeoutParameter, MinBackoffParameter, and MaxBackoffParameter of network URLs.
+// This package registers this factory with zap.RegisterSink for each of the syslog schemes.
+func NewSyslogSink(u *url.URL) (zap.Sink, error) {
+	s := &Syslog{
+		format:       SyslogRFC5424,
+		facility:     syslogFacilities["user"],
+		pid:          os.Getpid(),
+		writeTimeout: DefaultWriteTimeout,
+		minBackoff:   DefaultMinBackoff,
+		maxBackoff:   DefaultMaxBackoff,
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case SyslogScheme, SyslogTCPScheme:
+		s.network = "udp"
+		if strings.EqualFold(u.Scheme, SyslogTCPScheme) {
+			s.network = "tcp"
+		}
+
+		host, port := u.Hostname(), u.Port()
+		if len(host) == 0 {
+			host = "localhost"
+		}
+
+		if len(port) == 0 {
+			port = DefaultSyslogPort
+		}
+
+		s.address = net.JoinHostPort(host, port)
+
+	case SyslogUnixScheme:
+		s.network = "unix"
+		s.address = u.Path
+		if len(s.address) == 0 {
+			s.address = DefaultSyslogSocket
+		}
+
+	default:
+		return nil, fmt.Errorf("Invalid syslog scheme [%s]", u.Scheme) // nolint:staticcheck
+	}
+
+	values, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	if v := values.Get(FacilityParameter); len(v) > 0 {
+		var ok bool
+		if s.facility, ok = syslogFacilities[strings.ToLower(v)]; !ok {
+			s.facility, err = strconv.Atoi(v)
+			if err != nil || s.facility < 0 || s.facility > 23 {
+				return nil, fmt.Errorf("Invalid syslog facility [%s]", v) // nolint:staticcheck
+			}
+		}
+	}
+
+	switch v := strings.ToLower(values.Get(FormatParameter)); v {
+	case "", SyslogRFC5424:
+	case SyslogRFC3164:
+		s.format = v
+	default:
+		return nil, fmt.Errorf("Invalid syslog format [%s]", v) // nolint:staticcheck
+	}
+
+	for _, d := range []struct {
+		name  string
+		value *time.Duration
+	}{
+		{WriteTimeoutParameter, &s.writeTimeout},
+		{MinBackoffParameter, &s.minBackoff},
+		{MaxBackoffParameter, &s.maxBackoff},
+	} {
+		if v := values.Get(d.name); len(v) > 0 {
+			*d.value, err = time.ParseDuration(v)
+			if err != nil || *d.value <= 0 {
+				return nil, fmt.Errorf("Invalid syslog %s [%s]", d.name, v) // nolint:staticcheck
+			}
+		}
+	}
+
+	if s.maxBackoff < s.minBackoff {
+		s.maxBackoff = s.minBackoff
+	}
+
+	s.app = values.Get(AppNameParameter)
+	if len(s.app) == 0 {
+		s.app = filepath.Base(os.Args[0])
+	}
+
+	s.hostname = values.Get(HostnameParameter)
+	if len(s.hostname) == 0 {
+		s.hostname, _ = os.Hostname()
+	}
+
+	return s, nil
+}
+
+// message formats a syslog message, without any framing.
+func (s *Syslog) message(l zapcore.Level, p []byte) []byte {
+	var (
+		b        bytes.Buffer
+		pri      = s.facility*8 + SyslogSeverity(l)
+		now      = time.Now()
+		hostname = s.hostname
+	)
+
+	if len(hostname) == 0 {
+		hostname = "-"
+	}
+
+	if s.format == SyslogRFC3164 {
+		fmt.Fprintf(&b, "<%d>%s %s %s[%d]: ", pri, now.Format(time.Stamp), hostname, s.app, s.pid)
+	} else {
+		fmt.Fprintf(&b, "<%d>1 %s %s %s %d - - ", pri, now.Format("2006-01-02T15:04:05.000000Z07:00"), hostname, s.app, s.pid)
+	}
+
+	b.Write(bytes.TrimRight(p, "\n"))
+	return b.Bytes()
+}
+
+// dial connects to the syslog server.  For a unix socket, a datagram connection is
+// tried first.  While backing off from a failed attempt, the error from that attempt
+// is returned without dialing.  The lock must be held.
+func (s *Syslog) dial() (err error) {
+	if time.Now().Before(s.retryAt) {
+		return s.dialErr
+	}
+
+	if s.network == "unix" {
+		s.conn, err = net.DialTimeout("unixgram", s.address, s.writeTimeout)
+		if err == nil {
+			s.stream = false
+			s.backoff = 0
+			return
+		}
+	}
+
+	s.conn, err = net.DialTimeout(s.network, s.address, s.writeTimeout)
+	s.stream = err == nil && s.network != "udp"
+	if err == nil {
+		s.backoff = 0
+		return
+	}
+
+	s.conn = nil
+	s.backoff *= 2
+	switch {
+	case s.backoff < s.minBackoff:
+		s.backoff = s.minBackoff
+
+	case s.backoff > s.maxBackoff:
+		s.backoff = s.maxBackoff
+	}
+
+	s.retryAt = time.Now().Add(s.backoff)
+	s.dialErr = err
+	return
+}
+
+// send writes a message to the connection, framing it for stream connections.  RFC 5424
+// messages use octet counting, and RFC 3164 messages are terminated by newlines.  The lock
+// must be held.
+func (s *Syslog) send(msg []byte) (err error) {
+	if s.conn == nil {
+		err = s.dial()
+	}
+
+	if err == nil {
+		err = s.conn.SetWriteDeadline(time.Now().Add(s.writeTimeout))
+	}
+
+	if err == nil {
+		switch {
+		case !s.stream:
+			_, err = s.conn.Write(msg)
+
+		case s.format == SyslogRFC3164:
+			_, err = s.conn.Write(append(msg, '\n'))
+
+		default:
+			_, err = s.conn.Write(append([]byte(strconv.Itoa(len(msg))+" "), msg...))
+		}
+	}
+
+	if err != nil && s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+
+	return
+}
+
+// WriteLevel sends p as a single message with the severity for the given level.  Any trailing
+// newline is removed.  If sending over an existing connection fails, a new connection is made
+// and the message sent again.
+func (s *Syslog) WriteLevel(l zapcore.Level, p []byte) (int, error) {
+	msg := s.message(l, p)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	connected := s.conn != nil
+	err := s.send(msg)
+	if err != nil && connected {
+		err = s.send(msg)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Write sends p as a single message with informational severity.
+func (s *Syslog) Write(p []byte) (int, error) {
+	return s.WriteLevel(zapcore.InfoLevel, p)
+}
+
+// Sync is a nop, as each message is sent as it is written.
+func (s *Syslog) Sync() error {
+	return nil
+}
+
+// Close closes the connection to the syslog server, if any.
+func (s *Syslog) Close() (err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.conn != nil {
+		err = s.conn.Close()
+		s.conn = nil
+	}
+
+	return
+}
+
+// syslogWriter is the zapcore.WriteSyncer for a syslogCore.  The level is that of
+// the entry being written, and is guarded by the lock.
+type syslogWriter struct {
+	sink  *Syslog
+	lock  sync.Mutex
+	level zapcore.Level
+}
+
+func (sw *syslogWriter) Write(p []byte) (int, error) {
+	return sw.sink.WriteLevel(sw.level, p)
+}
+
+func (sw *syslogWriter) Sync() error {
+	return sw.sink.Sync()
+}
+
+// syslogCore writes each entry to a Syslog sink with the severity of the entry's level.
+type syslogCore struct {
+	zapcore.Core
+
+	writer *syslogWriter
+}
+
+var _ zapcore.Core = syslogCore{}
+
+// newSyslogCore creates a core with the encoding of the given zap.Config that writes
+// to a Syslog sink.
+func newSyslogCore(zc zap.Config, sink *Syslog) (zapcore.Core, error) {
+	sw := &syslogWriter{sink: sink}
+	core, err := newIOCore(zc, sw)
+	if err != nil {
+		return nil, err
+	}
+
+	return syslogCore{
+		Core:   core,
+		writer: sw,
+	}, nil
+}
+
+func (sc syslogCore) With(fields []zapcore.Field) zapcore.Core {
+	return syslogCore{
+		Core:   sc.Core.With(fields),
+		writer: sc.writer,
+	}
+}
+
+func (sc syslogCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if sc.Enabled(ent.Level) {
+		return ce.AddCore(ent, sc)
+	}
+
+	return ce
+}
+
+func (sc syslogCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	sc.writer.lock.Lock()
+	defer sc.writer.lock.Unlock()
+
+	sc.writer.level = ent.Level
+	return sc.Core.Write(ent, fields)
+}
+
+// splitSyslogPaths separates the syslog URLs from the other output paths, opening a
+// Syslog sink for each one.
+func splitSyslogPaths(paths []string) (others []string, sinks []*Syslog, err error) {
+	for _, p := range paths {
+		u, parseErr := url.Parse(p)
+		if parseErr != nil || !isSyslogScheme(u.Scheme) {
+			others = append(others, p)
+			continue
+		}
+
+		var sink zap.Sink
+		sink, err = NewSyslogSink(u)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		sinks = append(sinks, sink.(*Syslog))
+	}
+
+	return
+}
@@ -0,0 +1,281 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func testSyslogSeverity(t *testing.T) {
+	testData := []struct {
+		level    zapcore.Level
+		expected int
+	}{
+		{zapcore.DebugLevel - 1, 7},
+		{zapcore.DebugLevel, 7},
+		{zapcore.InfoLevel, 6},
+		{zapcore.WarnLevel, 4},
+		{zapcore.ErrorLevel, 3},
+		{zapcore.DPanicLevel, 2},
+		{zapcore.PanicLevel, 1},
+		{zapcore.FatalLevel, 0},
+	}
+
+	for _, record := range testData {
+		t.Run(record.level.String(), func(t *testing.T) {
+			assert.Equal(t, record.expected, SyslogSeverity(record.level))
+		})
+	}
+}
+
+func testSyslogInvalid(t *testing.T) {
+	for _, path := range []string{
+		"syslog://localhost?facility=nosuch",
+		"syslog://localhost?facility=24",
+		"syslog+tcp://localhost?format=rfc9999",
+		"syslog+tcp://localhost?writeTimeout=0",
+		"syslog://localhost?minBackoff=soon",
+		"lumberjack:///var/log/test.log",
+	} {
+		t.Run(path, func(t *testing.T) {
+			u, err := url.Parse(path)
+			require.NoError(t, err)
+
+			s, err := NewSyslogSink(u)
+			assert.Nil(t, s)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// testSyslogRead reads a single datagram from a connection.
+func testSyslogRead(t *testing.T, conn net.PacketConn) string {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, 64*1024)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	return string(buf[:n])
+}
+
+func testSyslogUDP(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(err)
+	defer conn.Close()
+
+	l, err := Config{
+		OutputPaths: []string{
+			fmt.Sprintf("syslog://%s?facility=local0&app=test&hostname=box", conn.LocalAddr()),
+		},
+		EncoderConfig: EncoderConfig{
+			MessageKey: "msg",
+		},
+	}.Build()
+
+	require.NoError(err)
+	defer l.Sync()
+
+	l.Error("first")
+	assert.Regexp(`^<131>1 \S+ box test \d+ - - \{.*"msg":"first"\}$`, testSyslogRead(t, conn))
+
+	l.Warn("second")
+	assert.Regexp(`^<132>1 \S+ box test \d+ - - \{.*"msg":"second"\}$`, testSyslogRead(t, conn))
+
+	l.Info("third")
+	assert.Regexp(`^<134>1 \S+ box test \d+ - - \{.*"msg":"third"\}$`, testSyslogRead(t, conn))
+}
+
+func testSyslogTCP(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer listener.Close()
+
+	l, err := Config{
+		OutputPaths: []string{
+			fmt.Sprintf("syslog+tcp://%s?format=rfc3164&app=test&hostname=box", listener.Addr()),
+			fmt.Sprintf("syslog+tcp://%s?app=test&hostname=box", listener.Addr()),
+		},
+		EncoderConfig: EncoderConfig{
+			MessageKey: "msg",
+		},
+	}.Build()
+
+	require.NoError(err)
+	l.Warn("message")
+
+	// the sinks connect in order
+	var readers [2]*bufio.Reader
+	for i := range readers {
+		conn, err := listener.Accept()
+		require.NoError(err)
+		defer conn.Close()
+		require.NoError(conn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+		readers[i] = bufio.NewReader(conn)
+	}
+
+	line, err := readers[0].ReadString('\n')
+	require.NoError(err)
+	assert.Regexp(`^<12>\w{3} [ \d]\d \d\d:\d\d:\d\d box test\[\d+\]: \{.*"msg":"message"\}\n$`, line)
+
+	// RFC 5424 messages use octet counting
+	length, err := readers[1].ReadString(' ')
+	require.NoError(err)
+	n, err := strconv.Atoi(strings.TrimSpace(length))
+	require.NoError(err)
+
+	msg := make([]byte, n)
+	_, err = io.ReadFull(readers[1], msg)
+	require.NoError(err)
+	assert.Regexp(`^<12>1 \S+ box test \d+ - - \{.*"msg":"message"\}$`, string(msg))
+}
+
+func testSyslogUnix(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		socket  = filepath.Join(t.TempDir(), "log")
+	)
+
+	conn, err := net.ListenPacket("unixgram", socket)
+	require.NoError(err)
+	defer conn.Close()
+
+	u := &url.URL{
+		Scheme:   SyslogUnixScheme,
+		Path:     socket,
+		RawQuery: "facility=daemon&app=test&hostname=box",
+	}
+
+	sink, closer, err := zap.Open(u.String())
+	require.NoError(err)
+	defer closer()
+
+	// without a level, messages are informational
+	_, err = sink.Write([]byte("plain\n"))
+	require.NoError(err)
+	assert.Regexp(`^<30>1 \S+ box test \d+ - - plain$`, testSyslogRead(t, conn))
+}
+
+func testSyslogBackoff(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	// find a port with nothing listening on it
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	u, err := url.Parse(fmt.Sprintf("syslog+tcp://%s?format=rfc3164&minBackoff=500ms", address))
+	require.NoError(err)
+	sink, err := NewSyslogSink(u)
+	require.NoError(err)
+	defer sink.Close()
+
+	_, dialErr := sink.Write([]byte("refused\n"))
+	require.Error(dialErr)
+
+	listener, err = net.Listen("tcp", address)
+	require.NoError(err)
+	defer listener.Close()
+
+	// while backing off, writes fail without dialing
+	_, err = sink.Write([]byte("backoff\n"))
+	assert.Equal(dialErr, err)
+
+	time.Sleep(600 * time.Millisecond)
+	_, err = sink.Write([]byte("connected\n"))
+	require.NoError(err)
+
+	conn, err := listener.Accept()
+	require.NoError(err)
+	defer conn.Close()
+	require.NoError(conn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(err)
+	assert.Contains(line, "connected")
+}
+
+func testSyslogWriteTimeout(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		socket  = filepath.Join(t.TempDir(), "log")
+	)
+
+	// a stream server that never reads
+	listener, err := net.Listen("unix", socket)
+	require.NoError(err)
+	defer listener.Close()
+
+	u := &url.URL{
+		Scheme:   SyslogUnixScheme,
+		Path:     socket,
+		RawQuery: "writeTimeout=100ms",
+	}
+
+	sink, err := NewSyslogSink(u)
+	require.NoError(err)
+	defer sink.Close()
+
+	start := time.Now()
+	_, err = sink.Write(bytes.Repeat([]byte{'x'}, 16*1024*1024))
+	assert.Error(err)
+	assert.Less(time.Since(start), 5*time.Second)
+}
+
+func testSyslogValidate(t *testing.T) {
+	assert.Equal(
+		t,
+		[]string{"outputPaths[1]", "cores[0].outputPaths[0]"},
+		validationPaths(t, Config{
+			OutputPaths: []string{
+				"syslog://localhost?facility=local7",
+				"syslog://localhost?facility=nosuch",
+			},
+			Cores: []CoreConfig{
+				{OutputPaths: []string{"syslog+unix:///dev/log?format=nosuch"}},
+			},
+			Permissions: "0600",
+		}.Validate()),
+	)
+}
+
+func TestSyslog(t *testing.T) {
+	t.Run("Severity", testSyslogSeverity)
+	t.Run("Invalid", testSyslogInvalid)
+	t.Run("Backoff", testSyslogBackoff)
+	t.Run("WriteTimeout", testSyslogWriteTimeout)
+	t.Run("UDP", testSyslogUDP)
+	t.Run("TCP", testSyslogTCP)
+	t.Run("Unix", testSyslogUnix)
+	t.Run("Validate", testSyslogValidate)
+}
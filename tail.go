@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// TailScheme is the URL scheme for streaming log entries to subscribers as they are
+	// written, e.g. tail://support.  The host is the name of the stream, which can be
+	// retrieved with GetTail.
+	TailScheme = "tail"
+
+	// DefaultTailBuffer is the number of entries held for a slow subscriber when
+	// Tail.Subscribe is given no buffer size.
+	DefaultTailBuffer = 100
+)
+
+var (
+	tailsLock sync.Mutex
+	tails     = map[string]*Tail{}
+)
+
+func init() {
+	registerSink(TailScheme, NewTailSink)
+}
+
+// isTailScheme tests if a URL scheme is TailScheme.
+func isTailScheme(scheme string) bool {
+	return strings.EqualFold(scheme, TailScheme)
+}
+
+// TailSubscription receives the entries written to a Tail that match its query.
+type TailSubscription struct {
+	tail    *Tail
+	query   MemoryQuery
+	entries chan MemoryEntry
+	dropped atomic.Uint64
+}
+
+// Entries returns the channel of entries for this subscription.  The channel is closed
+// when this subscription is closed.
+func (ts *TailSubscription) Entries() <-chan MemoryEntry {
+	return ts.entries
+}
+
+// Dropped returns the number of matching entries discarded because this subscription's
+// buffer was full.
+func (ts *TailSubscription) Dropped() uint64 {
+	return ts.dropped.Load()
+}
+
+// Close unsubscribes from the Tail and closes the Entries channel.  This method is idempotent.
+func (ts *TailSubscription) Close() {
+	ts.tail.unsubscribe(ts)
+}
+
+// Tail is a zap.Sink that streams log entries to subscribers as they are written.  Tail
+// sinks are named, and every tail URL with the same name shares the same subscribers.
+//
+// When a Config writes to a Tail that has no subscribers, its core is disabled so that
+// entries are not even encoded.  Each subscriber has a bounded buffer, and entries that
+// arrive while it is full are dropped rather than slowing down logging.
+//
+// A Tail is safe for concurrent use.  No additional synchronization is required.
+type Tail struct {
+	name string
+
+	lock        sync.Mutex
+	subscribers map[*TailSubscription]bool
+	count       atomic.Int32
+}
+
+var _ zap.Sink = (*Tail)(nil)
+
+// GetTail returns the Tail with the given name, creating it if necessary.  The result is
+// the same Tail that tail URLs with this name write to, whether or not the logger has been
+// built yet.
+func GetTail(name string) *Tail {
+	tailsLock.Lock()
+	defer tailsLock.Unlock()
+
+	t, ok := tails[name]
+	if !ok {
+		t = &Tail{
+			name:        name,
+			subscribers: make(map[*TailSubscription]bool),
+		}
+
+		tails[name] = t
+	}
+
+	return t
+}
+
+// parseTailURL returns the name described by a tail URL.
+func parseTailURL(u *url.URL) (string, error) {
+	switch {
+	case !isTailScheme(u.Scheme):
+		return "", fmt.Errorf("Invalid tail scheme [%s]", u.Scheme) // nolint:staticcheck
+
+	case len(u.Host) == 0:
+		return "", fmt.Errorf("Invalid tail URL [%s]: no name", u) // nolint:staticcheck
+
+	default:
+		return u.Host, nil
+	}
+}
+
+// NewTailSink returns the Tail named by the host of a tail URL, creating it if necessary.
+// This package registers this factory with zap.RegisterSink for TailScheme.
+func NewTailSink(u *url.URL) (zap.Sink, error) {
+	name, err := parseTailURL(u)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetTail(name), nil
+}
+
+// Name returns the name of this Tail.
+func (t *Tail) Name() string {
+	return t.name
+}
+
+// Subscribers returns the number of current subscriptions.
+func (t *Tail) Subscribers() int {
+	return int(t.count.Load())
+}
+
+// Subscribe starts receiving the entries that match a query.  The query's Limit is not
+// used.  The buffer is the number of entries held for a slow subscriber, and if it is not
+// positive DefaultTailBuffer is used.  The returned subscription must be closed when it is
+// no longer needed.
+func (t *Tail) Subscribe(mq MemoryQuery, buffer int) *TailSubscription {
+	if buffer < 1 {
+		buffer = DefaultTailBuffer
+	}
+
+	ts := &TailSubscription{
+		tail:    t,
+		query:   mq,
+		entries: make(chan MemoryEntry, buffer),
+	}
+
+	t.lock.Lock()
+	t.subscribers[ts] = true
+	t.count.Add(1)
+	t.lock.Unlock()
+
+	return ts
+}
+
+func (t *Tail) unsubscribe(ts *TailSubscription) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.subscribers[ts] {
+		delete(t.subscribers, ts)
+		t.count.Add(-1)
+		close(ts.entries)
+	}
+}
+
+// add sends an entry to each subscriber whose query it matches, without waiting.
+func (t *Tail) add(me MemoryEntry) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for ts := range t.subscribers {
+		if !ts.query.Match(me) {
+			continue
+		}
+
+		select {
+		case ts.entries <- me:
+		default:
+			ts.dropped.Add(1)
+		}
+	}
+}
+
+// active tests if this Tail has any subscribers.
+func (t *Tail) active() bool {
+	return t.count.Load() > 0
+}
+
+// Write sends a copy of p to the subscribers as an informational entry.
+func (t *Tail) Write(p []byte) (int, error) {
+	if t.active() {
+		t.add(MemoryEntry{
+			Time:    time.Now(),
+			Level:   zapcore.InfoLevel,
+			Encoded: append([]byte{}, p...),
+		})
+	}
+
+	return len(p), nil
+}
+
+// Sync does nothing, as entries are sent as soon as they are written.
+func (t *Tail) Sync() error {
+	return nil
+}
+
+// Close does nothing.  Subscriptions remain open, and continue to receive entries from
+// any other logger that writes to this Tail.
+func (t *Tail) Close() error {
+	return nil
+}
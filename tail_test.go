@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+func testTailInvalid(t *testing.T) {
+	for _, path := range []string{
+		"tail://",
+		"memory://test",
+	} {
+		t.Run(path, func(t *testing.T) {
+			u, err := url.Parse(path)
+			require.NoError(t, err)
+
+			s, err := NewTailSink(u)
+			assert.Nil(t, s)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func testTailConfig(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	l, err := Config{
+		Level:       "debug",
+		OutputPaths: []string{"tail://config"},
+	}.Build()
+
+	require.NoError(err)
+	tail := GetTail("config")
+	assert.Equal("config", tail.Name())
+
+	// without subscribers, nothing is even encoded
+	l.Error("unseen", zap.String("device", "1"))
+
+	sub := tail.Subscribe(
+		MemoryQuery{
+			Level:  zapcore.InfoLevel,
+			Fields: map[string]string{"device": "1"},
+		},
+		0,
+	)
+
+	defer sub.Close()
+	assert.Equal(1, tail.Subscribers())
+
+	l.Debug("filtered by level", zap.String("device", "1"))
+	l.Info("filtered by field", zap.String("device", "2"))
+	l.Named("device").Warn("matched", zap.String("device", "1"))
+
+	me := <-sub.Entries()
+	assert.Equal("matched", me.Message)
+	assert.Equal("device", me.LoggerName)
+	assert.Equal(zapcore.WarnLevel, me.Level)
+	assert.Contains(string(me.Encoded), `"msg":"matched"`)
+	assert.Empty(sub.Entries())
+}
+
+// testEncodedEntries counts the entries encoded by the testCountingEncoding.
+var testEncodedEntries atomic.Int32
+
+// testCountingEncoding is an encoding that counts the entries it encodes.
+const testCountingEncoding = "sallust-test-counting"
+
+type testCountingEncoder struct {
+	zapcore.Encoder
+}
+
+func (tce testCountingEncoder) Clone() zapcore.Encoder {
+	return testCountingEncoder{Encoder: tce.Encoder.Clone()}
+}
+
+func (tce testCountingEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	testEncodedEntries.Add(1)
+	return tce.Encoder.EncodeEntry(ent, fields)
+}
+
+func testTailNoSubscribers(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	// the encoding may already be registered by an earlier run of this test
+	zap.RegisterEncoder(testCountingEncoding, func(cfg zapcore.EncoderConfig) (zapcore.Encoder, error) { // nolint:errcheck
+		return testCountingEncoder{Encoder: zapcore.NewJSONEncoder(cfg)}, nil
+	})
+
+	l, err := Config{
+		Encoding:    testCountingEncoding,
+		OutputPaths: []string{"tail://noSubscribers"},
+		Levels:      map[string]string{"named": "debug"},
+	}.Build()
+
+	require.NoError(err)
+	testEncodedEntries.Store(0)
+
+	// the tail core declines entries, even after the level checks pass
+	assert.Nil(l.Check(zapcore.ErrorLevel, "unseen"))
+	assert.Nil(l.Named("named").Check(zapcore.DebugLevel, "unseen"))
+	assert.False(l.Core().Enabled(zapcore.ErrorLevel))
+	l.Error("unseen")
+	assert.Zero(testEncodedEntries.Load())
+
+	sub := GetTail("noSubscribers").Subscribe(MemoryQuery{}, 0)
+	defer sub.Close()
+
+	assert.NotNil(l.Check(zapcore.ErrorLevel, "seen"))
+	assert.True(l.Core().Enabled(zapcore.ErrorLevel))
+	l.Error("seen")
+	assert.Equal(int32(1), testEncodedEntries.Load())
+	assert.Equal("seen", (<-sub.Entries()).Message)
+}
+
+func testTailDropped(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	sink, closer, err := zap.Open("tail://dropped")
+	require.NoError(err)
+	defer closer()
+
+	tail := GetTail("dropped")
+	sub := tail.Subscribe(MemoryQuery{}, 1)
+	for _, p := range []string{"one\n", "two\n", "three\n"} {
+		_, err = sink.Write([]byte(p))
+		require.NoError(err)
+	}
+
+	assert.Equal(uint64(2), sub.Dropped())
+	me := <-sub.Entries()
+	assert.Equal("one\n", string(me.Encoded))
+	assert.Equal(zapcore.InfoLevel, me.Level)
+
+	sub.Close()
+	sub.Close()
+	assert.Zero(tail.Subscribers())
+
+	_, ok := <-sub.Entries()
+	assert.False(ok)
+}
+
+func testTailValidate(t *testing.T) {
+	assert.Equal(
+		t,
+		[]string{"outputPaths[1]"},
+		validationPaths(t, Config{
+			OutputPaths: []string{
+				"tail://valid",
+				"tail:///invalid",
+			},
+			Permissions: "0600",
+		}.Validate()),
+	)
+}
+
+func TestTail(t *testing.T) {
+	t.Run("Invalid", testTailInvalid)
+	t.Run("Config", testTailConfig)
+	t.Run("NoSubscribers", testTailNoSubscribers)
+	t.Run("Dropped", testTailDropped)
+	t.Run("Validate", testTailValidate)
+}
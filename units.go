@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// megabyte is the size of one of lumberjack's megabytes, which are actually mebibytes.
+	megabyte = 1024 * 1024
+
+	// day is the length of one of lumberjack's days.
+	day = 24 * time.Hour
+)
+
+// sizeUnits are the multipliers, in bytes, of the units accepted by ParseMegabytes.
+var sizeUnits = map[string]float64{
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+}
+
+// ParseMegabytes parses a file size into the megabytes used by lumberjack, each of which is
+// 1024*1024 bytes.  The text is either a plain integer number of megabytes, for compatibility
+// with lumberjack, or a number followed by one of the units B, KB, MB, GB, TB, KiB, MiB, GiB,
+// or TiB.  Units are not case sensitive.  Note that MB is a decimal unit of 1,000,000 bytes,
+// while MiB is lumberjack's megabyte.
+//
+// Sizes with units are rounded down to whole megabytes, so that files never grow beyond the
+// size given.  A positive size of less than one megabyte is an error, since lumberjack would
+// treat the resulting zero as its default of 100 megabytes.
+func ParseMegabytes(text string) (int, error) {
+	text = strings.TrimSpace(text)
+	if i, err := strconv.Atoi(text); err == nil && i >= 0 {
+		return i, nil
+	}
+
+	number := strings.TrimRightFunc(text, func(r rune) bool {
+		return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+	})
+
+	multiplier, ok := sizeUnits[strings.ToLower(strings.TrimSpace(text[len(number):]))]
+	value, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if !ok || err != nil || value < 0 || math.IsInf(value, 0) || math.IsNaN(value) {
+		return 0, fmt.Errorf("Invalid size [%s]", text) // nolint:staticcheck
+	}
+
+	mb := math.Floor(value * multiplier / megabyte)
+	switch {
+	case mb > math.MaxInt32:
+		return 0, fmt.Errorf("Invalid size [%s]: too large", text) // nolint:staticcheck
+
+	case mb == 0 && value > 0:
+		return 0, fmt.Errorf("Invalid size [%s]: less than 1MiB", text) // nolint:staticcheck
+	}
+
+	return int(mb), nil
+}
+
+// ParseDays parses an age into the whole days used by lumberjack.  The text is either a plain
+// integer number of days, for compatibility with lumberjack, a number of days with a "d" suffix
+// such as "14d", or any string accepted by time.ParseDuration such as "36h".
+//
+// Ages are rounded up to whole days, so that files are always kept for at least as long
+// as the age given.
+func ParseDays(text string) (int, error) {
+	text = strings.TrimSpace(text)
+	if i, err := strconv.Atoi(text); err == nil && i >= 0 {
+		return i, nil
+	}
+
+	var (
+		d   time.Duration
+		err error
+	)
+
+	if number, ok := strings.CutSuffix(strings.ToLower(text), "d"); ok {
+		var value float64
+		value, err = strconv.ParseFloat(number, 64)
+		if err == nil && (value < 0 || value*float64(day) > math.MaxInt64 || math.IsNaN(value)) {
+			err = strconv.ErrRange
+		}
+
+		d = time.Duration(value * float64(day))
+	} else {
+		d, err = time.ParseDuration(text)
+	}
+
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("Invalid age [%s]", text) // nolint:staticcheck
+	}
+
+	return int((d + day - 1) / day), nil
+}
+
+// rotationUnits replaces the sizes and ages with units in the decoded fields of a Rotation with
+// plain numbers of megabytes and days.  Field names are matched without regard to case, in the
+// same way as encoding/json and mapstructure.  An empty string is a zero size or age.
+func rotationUnits(fields map[string]interface{}) error {
+	for name, value := range fields {
+		var parse func(string) (int, error)
+		switch strings.ToLower(name) {
+		case "maxsize":
+			parse = ParseMegabytes
+
+		case "maxage":
+			parse = ParseDays
+
+		default:
+			continue
+		}
+
+		if text, ok := value.(string); ok {
+			var (
+				i   int
+				err error
+			)
+
+			if len(strings.TrimSpace(text)) > 0 {
+				i, err = parse(text)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			fields[name] = i
+		}
+	}
+
+	return nil
+}
+
+// unmarshalRotation sets a Rotation from its decoded fields, allowing units in its sizes and ages.
+func unmarshalRotation(fields map[string]interface{}, r *Rotation) error {
+	if err := rotationUnits(fields); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	// the conversion drops the methods of Rotation, so that this does not recurse
+	type rotation Rotation
+	return json.Unmarshal(data, (*rotation)(r))
+}
+
+// UnmarshalJSON decodes a Rotation, allowing MaxSize and MaxAge to be strings with units
+// as accepted by ParseMegabytes and ParseDays, e.g. {"maxsize": "250MiB", "maxage": "14d"}.
+// Plain numbers of megabytes and days are decoded as usual.
+func (r *Rotation) UnmarshalJSON(data []byte) error {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	return unmarshalRotation(fields, r)
+}
+
+// UnmarshalYAML decodes a Rotation in the same way as UnmarshalJSON.  This method has
+// the signature of the unmarshaler interface that YAML libraries support.
+func (r *Rotation) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var fields map[string]interface{}
+	if err := unmarshal(&fields); err != nil {
+		return err
+	}
+
+	return unmarshalRotation(fields, r)
+}
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testParseMegabytesValid(t *testing.T) {
+	testData := []struct {
+		text     string
+		expected int
+	}{
+		{"0", 0},
+		{"100", 100},
+		{"250MiB", 250},
+		{"250 mib", 250},
+		{"1.5GB", 1430},
+		{"100MB", 95},
+		{"2GiB", 2048},
+		{"1TiB", 1024 * 1024},
+		{"1048576B", 1},
+		{"1024KiB", 1},
+		{"0MiB", 0},
+	}
+
+	for _, record := range testData {
+		t.Run(record.text, func(t *testing.T) {
+			size, err := ParseMegabytes(record.text)
+			assert.NoError(t, err)
+			assert.Equal(t, record.expected, size)
+		})
+	}
+}
+
+func testParseMegabytesInvalid(t *testing.T) {
+	for _, text := range []string{"", "-1", "MiB", "10 furlongs", "-5MiB", "100KB", "1e20TB", "NaNMB"} {
+		t.Run(text, func(t *testing.T) {
+			size, err := ParseMegabytes(text)
+			assert.Error(t, err)
+			assert.Zero(t, size)
+		})
+	}
+}
+
+func TestParseMegabytes(t *testing.T) {
+	t.Run("Valid", testParseMegabytesValid)
+	t.Run("Invalid", testParseMegabytesInvalid)
+}
+
+func testParseDaysValid(t *testing.T) {
+	testData := []struct {
+		text     string
+		expected int
+	}{
+		{"0", 0},
+		{"7", 7},
+		{"14d", 14},
+		{"14D", 14},
+		{"1.5d", 2},
+		{"36h", 2},
+		{"24h", 1},
+		{"1h", 1},
+		{"0s", 0},
+	}
+
+	for _, record := range testData {
+		t.Run(record.text, func(t *testing.T) {
+			age, err := ParseDays(record.text)
+			assert.NoError(t, err)
+			assert.Equal(t, record.expected, age)
+		})
+	}
+}
+
+func testParseDaysInvalid(t *testing.T) {
+	for _, text := range []string{"", "-1", "d", "-2d", "-1h", "fortnight", "1w", "1e300d"} {
+		t.Run(text, func(t *testing.T) {
+			age, err := ParseDays(text)
+			assert.Error(t, err)
+			assert.Zero(t, age)
+		})
+	}
+}
+
+func TestParseDays(t *testing.T) {
+	t.Run("Valid", testParseDaysValid)
+	t.Run("Invalid", testParseDaysInvalid)
+}
+
+func testUnitsUnmarshalJSON(t *testing.T) {
+	testData := []struct {
+		json     string
+		expected Rotation
+	}{
+		{
+			json:     `{"maxsize": 100, "maxage": 7}`,
+			expected: Rotation{MaxSize: 100, MaxAge: 7},
+		},
+		{
+			json:     `{"maxsize": "250MiB", "maxage": "14d"}`,
+			expected: Rotation{MaxSize: 250, MaxAge: 14},
+		},
+		{
+			json:     `{"maxSize": "1.5GB", "maxAge": "36h", "maxbackups": 3}`,
+			expected: Rotation{MaxSize: 1430, MaxAge: 2, MaxBackups: 3},
+		},
+		{
+			json:     `{"maxsize": null, "maxage": ""}`,
+			expected: Rotation{},
+		},
+	}
+
+	for _, record := range testData {
+		t.Run(record.json, func(t *testing.T) {
+			var actual Rotation
+			require.NoError(t, json.Unmarshal([]byte(record.json), &actual))
+			assert.Equal(t, record.expected, actual)
+		})
+	}
+}
+
+func testUnitsUnmarshalJSONInvalid(t *testing.T) {
+	for _, text := range []string{
+		`{"maxsize": "lots"}`,
+		`{"maxsize": 1.5}`,
+		`{"maxage": "forever"}`,
+		`{"maxage": true}`,
+	} {
+		t.Run(text, func(t *testing.T) {
+			var r Rotation
+			assert.Error(t, json.Unmarshal([]byte(text), &r))
+		})
+	}
+}
+
+func testUnitsUnmarshalYAML(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+
+		// stands in for a YAML library, which decodes the document into the map
+		unmarshal = func(document string) func(interface{}) error {
+			return func(v interface{}) error {
+				return json.Unmarshal([]byte(document), v)
+			}
+		}
+
+		actual Rotation
+	)
+
+	require.NoError(actual.UnmarshalYAML(unmarshal(`{"maxsize": "2GiB", "maxage": "1.5d", "compress": true}`)))
+	assert.Equal(Rotation{MaxSize: 2048, MaxAge: 2, Compress: true}, actual)
+
+	assert.Error(actual.UnmarshalYAML(unmarshal(`{"maxsize": "lots"}`)))
+	assert.Error(actual.UnmarshalYAML(unmarshal(`["not", "a", "map"]`)))
+}
+
+func testUnitsConfig(t *testing.T) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+		c       Config
+	)
+
+	// units work wherever a Rotation is decoded
+	require.NoError(json.Unmarshal(
+		[]byte(`{"rotation": {"maxsize": "10MiB"}, "cores": [{"rotation": {"maxage": "48h"}}]}`),
+		&c,
+	))
+
+	require.NotNil(c.Rotation)
+	assert.Equal(10, c.Rotation.MaxSize)
+	require.Len(c.Cores, 1)
+	require.NotNil(c.Cores[0].Rotation)
+	assert.Equal(2, c.Cores[0].Rotation.MaxAge)
+}
+
+func TestUnits(t *testing.T) {
+	t.Run("UnmarshalJSON", testUnitsUnmarshalJSON)
+	t.Run("UnmarshalJSONInvalid", testUnitsUnmarshalJSONInvalid)
+	t.Run("UnmarshalYAML", testUnitsUnmarshalYAML)
+	t.Run("Config", testUnitsConfig)
+}
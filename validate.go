@@ -0,0 +1,392 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	// knownLevelEncoders are the names honored by zapcore.LevelEncoder.UnmarshalText.
+	knownLevelEncoders = map[string]bool{
+		"capital":      true,
+		"capitalColor": true,
+		"color":        true,
+		"lowercase":    true,
+	}
+
+	// knownTimeEncoders are the names honored by zapcore.TimeEncoder.UnmarshalText.
+	knownTimeEncoders = map[string]bool{
+		"rfc3339nano": true,
+		"RFC3339Nano": true,
+		"rfc3339":     true,
+		"RFC3339":     true,
+		"iso8601":     true,
+		"ISO8601":     true,
+		"millis":      true,
+		"nanos":       true,
+		"epoch":       true,
+	}
+
+	// knownDurationEncoders are the names honored by zapcore.DurationEncoder.UnmarshalText.
+	knownDurationEncoders = map[string]bool{
+		"string":  true,
+		"nanos":   true,
+		"ms":      true,
+		"seconds": true,
+	}
+
+	// knownCallerEncoders are the names honored by zapcore.CallerEncoder.UnmarshalText.
+	knownCallerEncoders = map[string]bool{
+		"full":  true,
+		"short": true,
+	}
+
+	// knownNameEncoders are the names honored by zapcore.NameEncoder.UnmarshalText.
+	knownNameEncoders = map[string]bool{
+		"full": true,
+	}
+
+	// knownBufferModes are the values honored by BufferingConfig.OnFull.
+	knownBufferModes = map[string]bool{
+		BufferBlock: true,
+		BufferDrop:  true,
+	}
+)
+
+// ValidationError describes one problem found by Validate.
+type ValidationError struct {
+	// Path is the location of the offending field, using the same names as the
+	// JSON representation, e.g. "encoderConfig.timeEncoder" or "cores[1].outputPaths[0]".
+	Path string
+
+	// Err is the problem with the field's value.
+	Err error
+}
+
+func (ve *ValidationError) Error() string {
+	return ve.Path + ": " + ve.Err.Error()
+}
+
+func (ve *ValidationError) Unwrap() error {
+	return ve.Err
+}
+
+// validator accumulates ValidationErrors.
+type validator struct {
+	errs []error
+
+	// schemes is set if output paths must use known sink schemes
+	schemes bool
+}
+
+// add records an error for the field at the given path.  A nil error is ignored.
+func (v *validator) add(path string, err error) {
+	if err != nil {
+		v.errs = append(v.errs, &ValidationError{Path: path, Err: err})
+	}
+}
+
+// err joins all the recorded errors.  If there are no errors, this method returns nil.
+func (v *validator) err() error {
+	return errors.Join(v.errs...)
+}
+
+// joinPath builds a dotted field path.
+func joinPath(prefix, name string) string {
+	if len(prefix) == 0 {
+		return name
+	}
+
+	return prefix + "." + name
+}
+
+// indexPath builds a field path for an element of a slice or map.
+func indexPath(prefix string, index interface{}) string {
+	return fmt.Sprintf("%s[%v]", prefix, index)
+}
+
+func unknownValue(text string) error {
+	return fmt.Errorf("unknown value %q", text)
+}
+
+func validateLevel(v *validator, path, text string) {
+	if len(text) > 0 {
+		var l zapcore.Level
+		v.add(path, l.UnmarshalText([]byte(text)))
+	}
+}
+
+func validateKnown(v *validator, path, text string, known map[string]bool) {
+	if len(text) > 0 && !known[text] {
+		v.add(path, unknownValue(text))
+	}
+}
+
+// validateEncoding checks that an encoding has been registered with zap.
+func validateEncoding(v *validator, path, encoding string) {
+	if len(encoding) > 0 {
+		zc := zap.Config{
+			Level:    zap.NewAtomicLevel(),
+			Encoding: encoding,
+		}
+
+		if _, err := zc.Build(); err != nil {
+			v.add(path, unknownValue(encoding))
+		}
+	}
+}
+
+// validatePaths checks that each path can be parsed, and that the URLs of this package's
+// sinks are valid and use schemes that this package was able to register.  If the validator
+// checks schemes, a path with a scheme that was never registered or declared is reported.
+func validatePaths(v *validator, path string, paths []string, mapping func(string) string) {
+	for i, p := range paths {
+		if mapping != nil {
+			p = os.Expand(p, mapping)
+		}
+
+		if p == Stdout || p == Stderr || filepath.IsAbs(p) {
+			continue
+		}
+
+		u, err := url.Parse(p)
+		if err == nil {
+			err = sinkError(u.Scheme)
+		}
+
+		switch {
+		case err != nil:
+			v.add(indexPath(path, i), err)
+
+		case v.schemes && len(u.Scheme) > 0 && !isSinkScheme(u.Scheme):
+			v.add(indexPath(path, i), fmt.Errorf("unknown scheme %q", u.Scheme))
+
+		case strings.EqualFold(u.Scheme, LumberjackScheme):
+			_, err = parseLumberjackURL(u)
+			v.add(indexPath(path, i), err)
+
+		case isNetworkScheme(u.Scheme):
+			v.add(indexPath(path, i), validateNetworkURL(u))
+
+		case isMemoryScheme(u.Scheme):
+			_, _, err = parseMemoryURL(u)
+			v.add(indexPath(path, i), err)
+
+		case isTailScheme(u.Scheme):
+			_, err = parseTailURL(u)
+			v.add(indexPath(path, i), err)
+		}
+	}
+}
+
+// validateNetworkURL checks the parameters of a network sink URL without connecting.
+func validateNetworkURL(u *url.URL) (err error) {
+	switch strings.ToLower(u.Scheme) {
+	case HTTPScheme, HTTPSScheme:
+		_, err = newHTTPBatch(u)
+
+	case TCPScheme, UDPScheme:
+		_, err = newNetwork(u)
+
+	default:
+		// creating a syslog sink only parses its URL
+		_, err = NewSyslogSink(u)
+	}
+
+	return
+}
+
+func validatePermissions(v *validator, path, perms string) {
+	_, err := ParsePermissions(perms)
+	v.add(path, err)
+}
+
+func validateNonNegative(v *validator, path string, value int) {
+	if value < 0 {
+		v.add(path, fmt.Errorf("cannot be negative: %d", value))
+	}
+}
+
+func (ec EncoderConfig) validate(v *validator, prefix string) {
+	validateKnown(v, joinPath(prefix, "levelEncoder"), ec.EncodeLevel, knownLevelEncoders)
+	if layout, ok := strings.CutPrefix(ec.EncodeTime, TimeLayoutPrefix); ok {
+		if len(layout) == 0 {
+			v.add(joinPath(prefix, "timeEncoder"), errors.New("empty time layout"))
+		}
+	} else {
+		validateKnown(v, joinPath(prefix, "timeEncoder"), ec.EncodeTime, knownTimeEncoders)
+	}
+
+	if len(ec.TimeZone) > 0 {
+		if ec.UTC {
+			v.add(joinPath(prefix, "timeZone"), errors.New("cannot be combined with utc"))
+		} else if _, err := time.LoadLocation(ec.TimeZone); err != nil {
+			v.add(joinPath(prefix, "timeZone"), err)
+		}
+	}
+
+	validateKnown(v, joinPath(prefix, "durationEncoder"), ec.EncodeDuration, knownDurationEncoders)
+	validateKnown(v, joinPath(prefix, "callerEncoder"), ec.EncodeCaller, knownCallerEncoders)
+	validateKnown(v, joinPath(prefix, "nameEncoder"), ec.EncodeName, knownNameEncoders)
+}
+
+// Validate checks each of the encoder names and the time zone in this EncoderConfig.  Unlike
+// NewZapcoreEncoderConfig, which lets zapcore fall back to defaults, an unknown
+// name is reported as an error.  All problems are returned together as
+// *ValidationError instances joined with errors.Join.
+func (ec EncoderConfig) Validate() error {
+	var v validator
+	ec.validate(&v, "")
+	return v.err()
+}
+
+func (r Rotation) validate(v *validator, prefix string) {
+	validateNonNegative(v, joinPath(prefix, "maxsize"), r.MaxSize)
+	validateNonNegative(v, joinPath(prefix, "maxage"), r.MaxAge)
+	validateNonNegative(v, joinPath(prefix, "maxbackups"), r.MaxBackups)
+	if len(r.Schedule) > 0 {
+		_, err := ParseSchedule(r.Schedule)
+		v.add(joinPath(prefix, "schedule"), err)
+	}
+
+	if len(r.FsyncLevel) > 0 {
+		var l zapcore.Level
+		v.add(joinPath(prefix, "fsynclevel"), l.UnmarshalText([]byte(r.FsyncLevel)))
+	}
+}
+
+// Validate checks that none of the numeric options of this Rotation are negative, and that
+// any Schedule and FsyncLevel are valid.  All problems are returned together as *ValidationError instances
+// joined with errors.Join.
+func (r Rotation) Validate() error {
+	var v validator
+	r.validate(&v, "")
+	return v.err()
+}
+
+func validatePattern(v *validator, prefix, pattern string) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		v.add(prefix, fmt.Errorf("invalid pattern %q: %w", pattern, err))
+	}
+}
+
+func validatePatterns(v *validator, prefix string, patterns []string) {
+	for i, p := range patterns {
+		validatePattern(v, indexPath(prefix, i), p)
+	}
+}
+
+func (rc RedactConfig) validate(v *validator, prefix string) {
+	validatePatterns(v, joinPath(prefix, "mask"), rc.Mask)
+	validatePatterns(v, joinPath(prefix, "hash"), rc.Hash)
+	validatePatterns(v, joinPath(prefix, "drop"), rc.Drop)
+}
+
+func (bc BufferingConfig) validate(v *validator, prefix string) {
+	validateNonNegative(v, joinPath(prefix, "size"), bc.Size)
+	validateNonNegative(v, joinPath(prefix, "flushInterval"), int(bc.FlushInterval))
+	validateKnown(v, joinPath(prefix, "onFull"), bc.OnFull, knownBufferModes)
+}
+
+func (r SamplingRule) validate(v *validator, prefix string) {
+	validateLevel(v, joinPath(prefix, "level"), r.Level)
+	validatePattern(v, joinPath(prefix, "message"), r.Message)
+	validateNonNegative(v, joinPath(prefix, "initial"), r.Initial)
+	validateNonNegative(v, joinPath(prefix, "thereafter"), r.Thereafter)
+	validateNonNegative(v, joinPath(prefix, "tick"), int(r.Tick))
+}
+
+func (cc CoreConfig) validate(v *validator, prefix string, mapping func(string) string) {
+	validateLevel(v, joinPath(prefix, "level"), cc.Level)
+	validateEncoding(v, joinPath(prefix, "encoding"), cc.Encoding)
+	cc.EncoderConfig.validate(v, joinPath(prefix, "encoderConfig"))
+	validatePaths(v, joinPath(prefix, "outputPaths"), cc.OutputPaths, mapping)
+	validatePermissions(v, joinPath(prefix, "permissions"), cc.Permissions)
+	if cc.Rotation != nil {
+		cc.Rotation.validate(v, joinPath(prefix, "rotation"))
+	}
+}
+
+// Validate checks this Config for problems, including those that zap would otherwise
+// silently ignore such as unknown encoder names.  Every problem is reported, not just
+// the first.  The returned error joins *ValidationError instances with errors.Join, and
+// each one is addressed by its field path, e.g.:
+//
+//	encoderConfig.timeEncoder: unknown value "iso8061"
+//
+// Any Preset is applied before the other fields are checked.  Output paths are checked
+// after path expansion, including the query parameters of this package's sink URLs.  A path
+// with a URL scheme is only considered valid if its sink is one of this package's, is zap's
+// file sink, or was registered with RegisterSink or declared with DeclareSinkScheme.  Build
+// leaves other schemes for zap to report, so that sinks registered directly with
+// zap.RegisterSink keep working.
+func (c Config) Validate() error {
+	v := validator{schemes: true}
+	if resolved, err := c.ApplyPreset(); err != nil {
+		v.errs = append(v.errs, err)
+	} else {
+		c = resolved
+	}
+
+	c.validate(&v)
+	return v.err()
+}
+
+// validate checks a Config whose Preset has already been applied.
+func (c Config) validate(v *validator) {
+	mapping := c.mapping()
+	validateLevel(v, "level", c.Level)
+
+	names := make([]string, 0, len(c.Levels))
+	for name := range c.Levels {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	for _, name := range names {
+		validateLevel(v, indexPath("levels", name), c.Levels[name])
+	}
+
+	validateEncoding(v, "encoding", c.Encoding)
+	c.EncoderConfig.validate(v, "encoderConfig")
+	validatePaths(v, "outputPaths", c.OutputPaths, mapping)
+	validatePaths(v, "errorOutputPaths", c.ErrorOutputPaths, mapping)
+	validatePermissions(v, "permissions", c.Permissions)
+	if c.Rotation != nil {
+		c.Rotation.validate(v, "rotation")
+	}
+
+	for i, cc := range c.Cores {
+		cc.validate(v, indexPath("cores", i), mapping)
+	}
+
+	for i, r := range c.SamplingRules {
+		r.validate(v, indexPath("samplingRules", i))
+	}
+
+	validateNonNegative(v, "samplingSummary", int(c.SamplingSummary))
+	if c.Redact != nil {
+		c.Redact.validate(v, "redact")
+	}
+
+	if c.Dedupe != nil {
+		validateNonNegative(v, "dedupe.window", int(c.Dedupe.Window))
+	}
+
+	if c.Buffering != nil {
+		c.Buffering.validate(v, "buffering")
+	}
+}
@@ -0,0 +1,249 @@
+// SPDX-FileCopyrightText: 2026 Comcast Cable Communications Management, LLC
+// SPDX-License-Identifier: Apache-2.0
+
+package sallust
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validationPaths extracts the field paths from the errors returned by Validate.
+func validationPaths(t *testing.T, err error) []string {
+	require.Error(t, err)
+	joined, ok := err.(interface{ Unwrap() []error })
+	require.True(t, ok)
+
+	var paths []string
+	for _, e := range joined.Unwrap() {
+		var ve *ValidationError
+		require.True(t, errors.As(e, &ve))
+		paths = append(paths, ve.Path)
+	}
+
+	return paths
+}
+
+func testValidateValid(t *testing.T) {
+	c := Config{
+		Level:  "debug",
+		Levels: map[string]string{"fx": "warn"},
+		EncoderConfig: EncoderConfig{
+			EncodeLevel:    "capital",
+			EncodeTime:     "iso8601",
+			EncodeDuration: "string",
+			EncodeCaller:   "short",
+			EncodeName:     "full",
+		},
+		Encoding:         "console",
+		OutputPaths:      []string{Stdout, "/var/log/test.log", "file:///var/log/test.log", "lumberjack:///var/log/test.log", "relative.log"},
+		ErrorOutputPaths: []string{Stderr},
+		Permissions:      "0644",
+		Rotation:         &Rotation{MaxSize: 100},
+		Cores: []CoreConfig{
+			{
+				Level:       "info",
+				Encoding:    "json",
+				OutputPaths: []string{"${LOG_DIR}/core.log"},
+			},
+		},
+		Mapping: func(string) string { return "/var/log" },
+	}
+
+	assert.NoError(t, c.Validate())
+	assert.NoError(t, Config{}.Validate())
+}
+
+func testValidateInvalid(t *testing.T) {
+	c := Config{
+		Level:  "loud",
+		Levels: map[string]string{"fx": "quiet", "http": "debug"},
+		EncoderConfig: EncoderConfig{
+			EncodeLevel:    "upper",
+			EncodeTime:     "iso8061",
+			EncodeDuration: "hours",
+			EncodeCaller:   "medium",
+			EncodeName:     "short",
+		},
+		Encoding:         "xml",
+		OutputPaths:      []string{Stdout, "memory://", "%zz"},
+		ErrorOutputPaths: []string{"memory://errors?size=0"},
+		Permissions:      "77",
+		Rotation:         &Rotation{MaxSize: -1, MaxAge: -1, MaxBackups: -1},
+		Cores: []CoreConfig{
+			{},
+			{
+				Level:         "loud",
+				Encoding:      "xml",
+				EncoderConfig: EncoderConfig{EncodeTime: "iso8061"},
+				OutputPaths:   []string{"tail://"},
+				Permissions:   "abc",
+				Rotation:      &Rotation{MaxAge: -1},
+			},
+		},
+	}
+
+	err := c.Validate()
+	assert.Equal(
+		t,
+		[]string{
+			"level",
+			"levels[fx]",
+			"encoding",
+			"encoderConfig.levelEncoder",
+			"encoderConfig.timeEncoder",
+			"encoderConfig.durationEncoder",
+			"encoderConfig.callerEncoder",
+			"encoderConfig.nameEncoder",
+			"outputPaths[1]",
+			"outputPaths[2]",
+			"errorOutputPaths[0]",
+			"permissions",
+			"rotation.maxsize",
+			"rotation.maxage",
+			"rotation.maxbackups",
+			"cores[1].level",
+			"cores[1].encoding",
+			"cores[1].encoderConfig.timeEncoder",
+			"cores[1].outputPaths[0]",
+			"cores[1].permissions",
+			"cores[1].rotation.maxage",
+		},
+		validationPaths(t, err),
+	)
+
+	assert.Contains(t, err.Error(), `encoderConfig.timeEncoder: unknown value "iso8061"`)
+	assert.Contains(t, err.Error(), `outputPaths[1]: Invalid memory URL [memory:]: no name`)
+
+	_, err = c.NewZapConfig()
+	assert.Error(t, err)
+
+	_, err = c.Build()
+	assert.Error(t, err)
+}
+
+func testValidateDisablePathExpansion(t *testing.T) {
+	c := Config{
+		OutputPaths:          []string{"${PREFIX}/foo"},
+		DisablePathExpansion: true,
+		Mapping:              func(string) string { return "%zz" },
+	}
+
+	// without expansion, the path is a plain relative file
+	assert.NoError(t, c.Validate())
+
+	c.DisablePathExpansion = false
+	assert.Equal(t, []string{"outputPaths[0]"}, validationPaths(t, c.Validate()))
+}
+
+func testValidateUnknownScheme(t *testing.T) {
+	c := Config{
+		OutputPaths:      []string{"nosuchscheme://foo"},
+		ErrorOutputPaths: []string{"declaredscheme://bar"},
+	}
+
+	err := c.Validate()
+	assert.Equal(t, []string{"outputPaths[0]", "errorOutputPaths[0]"}, validationPaths(t, err))
+	assert.Contains(t, err.Error(), `outputPaths[0]: unknown scheme "nosuchscheme"`)
+
+	// a sink registered directly with zap is accepted once its scheme is declared
+	DeclareSinkScheme("NoSuchScheme", "declaredscheme")
+	assert.NoError(t, c.Validate())
+
+	// Build leaves schemes to zap, which has no such sinks
+	_, err = c.Build()
+	assert.Error(t, err)
+}
+
+func testValidateLumberjackURL(t *testing.T) {
+	assert.NoError(
+		t,
+		Config{
+			OutputPaths: []string{"lumberjack:///var/log/test.log?maxSize=10MiB&maxAge=7d&maxBackups=3&schedule=daily&fsync=true&fsyncLevel=error"},
+		}.Validate(),
+	)
+
+	assert.Equal(
+		t,
+		[]string{
+			"outputPaths[0]",
+			"outputPaths[1]",
+			"outputPaths[2]",
+			"outputPaths[3]",
+			"outputPaths[4]",
+			"outputPaths[5]",
+		},
+		validationPaths(t, Config{
+			OutputPaths: []string{
+				"lumberjack:///var/log/test.log?maxSize=lots",
+				"lumberjack:///var/log/test.log?maxAge=forever",
+				"lumberjack:///var/log/test.log?maxBackups=-1",
+				"lumberjack:///var/log/test.log?schedule=weekly",
+				"lumberjack:///var/log/test.log?fsync=maybe",
+				"lumberjack:///var/log/test.log?fsyncLevel=nosuch",
+			},
+		}.Validate()),
+	)
+}
+
+func testEncoderConfigValidate(t *testing.T) {
+	assert.NoError(t, EncoderConfig{}.Validate())
+	assert.Equal(
+		t,
+		[]string{"timeEncoder"},
+		validationPaths(t, EncoderConfig{EncodeTime: "iso8061"}.Validate()),
+	)
+}
+
+func testEncoderConfigValidateTime(t *testing.T) {
+	assert.NoError(t, EncoderConfig{EncodeTime: "layout:2006-01-02", TimeZone: "America/New_York"}.Validate())
+	assert.NoError(t, EncoderConfig{EncodeTime: "layout:2006-01-02", UTC: true}.Validate())
+	assert.Equal(
+		t,
+		[]string{"timeEncoder", "timeZone"},
+		validationPaths(t, EncoderConfig{EncodeTime: "layout:", TimeZone: "Not/A_Zone"}.Validate()),
+	)
+
+	assert.Equal(
+		t,
+		[]string{"timeZone"},
+		validationPaths(t, EncoderConfig{TimeZone: "UTC", UTC: true}.Validate()),
+	)
+}
+
+func testRotationValidate(t *testing.T) {
+	assert.NoError(t, Rotation{}.Validate())
+	assert.Equal(
+		t,
+		[]string{"maxsize"},
+		validationPaths(t, Rotation{MaxSize: -1}.Validate()),
+	)
+
+	assert.NoError(t, Rotation{Schedule: ScheduleDaily}.Validate())
+	assert.Equal(
+		t,
+		[]string{"schedule"},
+		validationPaths(t, Rotation{Schedule: "weekly"}.Validate()),
+	)
+
+	assert.NoError(t, Rotation{Fsync: true, FsyncLevel: "error"}.Validate())
+	assert.Equal(
+		t,
+		[]string{"fsynclevel"},
+		validationPaths(t, Rotation{FsyncLevel: "nosuch"}.Validate()),
+	)
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("Valid", testValidateValid)
+	t.Run("Invalid", testValidateInvalid)
+	t.Run("DisablePathExpansion", testValidateDisablePathExpansion)
+	t.Run("UnknownScheme", testValidateUnknownScheme)
+	t.Run("LumberjackURL", testValidateLumberjackURL)
+	t.Run("EncoderConfig", testEncoderConfigValidate)
+	t.Run("EncoderConfigTime", testEncoderConfigValidateTime)
+	t.Run("Rotation", testRotationValidate)
+}